@@ -0,0 +1,162 @@
+package uuid
+
+import "unsafe"
+
+// FillV1 fills the slice with version 1 UUIDs using the default package
+// Generator.
+func FillV1(pDst []ID) error {
+	return generator.Load().FillV1(pDst)
+}
+
+// FillV4 fills the slice with version 4 UUIDs using the default package
+// Generator.
+func FillV4(pDst []ID) error {
+	return generator.Load().FillV4(pDst)
+}
+
+// FillV6 fills the slice with version 6 UUIDs using the default package
+// Generator.
+func FillV6(pDst []ID) error {
+	return generator.Load().FillV6(pDst)
+}
+
+// FillV7 fills the slice with version 7 UUIDs using the default package
+// Generator.
+func FillV7(pDst []ID) error {
+	return generator.Load().FillV7(pDst)
+}
+
+// FillV1 fills the slice with version 1 UUIDs while holding the lock once. A
+// block of consecutive timestamps is reserved starting from a single call to
+// Next, so the timestamps may run ahead of the clock by one 100ns tick per
+// UUID. Later UUIDs stay unique as the clock sequence is incremented when
+// Next falls behind. The error is from initialising the Generator.
+func (o *Generator) FillV1(pDst []ID) error {
+	return o.fillTime(pDst, func(pId *array, pNow Timestamp) {
+		makeUuid(pId,
+			uint32(pNow),
+			uint16(pNow>>32),
+			uint16(pNow>>48),
+			uint16(o.Sequence),
+			o.Node)
+		pId.setRFC4122Version(1)
+	})
+}
+
+// FillV6 is the same as FillV1 but fills the slice with version 6 UUIDs.
+func (o *Generator) FillV6(pDst []ID) error {
+	return o.fillTime(pDst, func(pId *array, pNow Timestamp) {
+		makeUuid(pId,
+			uint32(pNow>>28),
+			uint16(pNow>>12),
+			uint16(pNow&0x0fff),
+			uint16(o.Sequence),
+			o.Node)
+		pId.setRFC4122Version(6)
+	})
+}
+
+func (o *Generator) fillTime(pDst []ID, fMake func(*array, Timestamp)) error {
+	if err := o.Init(); err != nil {
+		return err
+	}
+	if len(pDst) == 0 {
+		return nil
+	}
+	if o.Saver != nil {
+		defer o.save()
+	}
+
+	o.Lock()
+	defer o.Unlock()
+
+	now := o.Next()
+	if now <= o.Timestamp {
+		o.Sequence++
+	}
+	for i := range pDst {
+		fMake((*array)(&pDst[i]), now+Timestamp(i))
+	}
+	o.Timestamp = now + Timestamp(len(pDst)-1)
+	return nil
+}
+
+// FillV4 fills the slice with version 4 UUIDs from a single read of the
+// Generator's Random function straight into the slice without taking the
+// lock. If Random fails HandleError is applied as for NewV4E and the slice is
+// filled with the Nil ID.
+func (o *Generator) FillV4(pDst []ID) error {
+	return o.retryFill("uuid.V4", pDst, o.fillV4)
+}
+
+func (o *Generator) fillV4(pDst []ID) error {
+	if _, err := o.Random(idBytes(pDst)); err != nil {
+		return err
+	}
+	for i := range pDst {
+		(*array)(&pDst[i]).setRFC4122Version(4)
+	}
+	return nil
+}
+
+// FillV7 fills the slice with version 7 UUIDs from a single read of the
+// Generator's Random function straight into the slice. The lock is then held
+// once for a single call to Next and to apply the V7Mode to each UUID in turn,
+// so in the monotonic modes the slice is in order. Errors are handled as for
+// FillV4.
+func (o *Generator) FillV7(pDst []ID) error {
+	return o.retryFill("uuid.V7", pDst, o.fillV7)
+}
+
+func (o *Generator) fillV7(pDst []ID) error {
+	if _, err := o.Random(idBytes(pDst)); err != nil {
+		return err
+	}
+
+	o.Lock()
+	defer o.Unlock()
+
+	now := o.Next().UnixMilli()
+	for i := range pDst {
+		id := (*array)(&pDst[i])
+
+		milli := now
+		switch o.V7Mode {
+		case V7Counter:
+			o.nextV7Counter(id, now)
+			milli = o.v7.milli
+		case V7MonotonicRandom:
+			o.nextV7MonotonicRandom(id, now)
+			milli = o.v7.milli
+		}
+
+		setUnixMilli(id, milli)
+		id.setRFC4122Version(7)
+	}
+	return nil
+}
+
+// retryFill is the same as retry for the Fill methods. The slice is cleared
+// if the last attempt fails.
+func (o *Generator) retryFill(pName string, pDst []ID, fFill func([]ID) error) error {
+	if len(pDst) == 0 {
+		return nil
+	}
+	err := fFill(pDst)
+	if err != nil && o.handle(pName, err, o.handleErrorE()) {
+		err = fFill(pDst)
+		if err != nil {
+			o.handle(pName, err, nil)
+		}
+	}
+	if err != nil {
+		clear(pDst)
+	}
+	return err
+}
+
+// idBytes returns the bytes backing the slice of IDs so that they can be
+// filled by a single read
+func idBytes(pIds []ID) []byte {
+	return unsafe.Slice(&pIds[0][0], len(pIds)*length)
+}
@@ -0,0 +1,197 @@
+package uuid
+
+import (
+	"crypto/rand"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const fillSize = 1024
+
+func TestGenerator_FillV4(t *testing.T) {
+	reads := 0
+	gen := newGenerator(GeneratorConfig{
+		Random: func(pDst []byte) (int, error) {
+			reads++
+			return rand.Read(pDst)
+		},
+	})
+
+	ids := make([]ID, fillSize)
+	assert.NoError(t, gen.FillV4(ids))
+	assert.Equal(t, 1, reads, "Random should be read once")
+
+	seen := make(map[ID]bool, len(ids))
+	for _, id := range ids {
+		assert.Equal(t, Four, id.Version())
+		assert.Equal(t, VariantRFC4122, id.Variant())
+		assert.False(t, seen[id], "Each UUID should be unique")
+		seen[id] = true
+	}
+
+	assert.NoError(t, gen.FillV4(nil))
+	assert.Equal(t, 1, reads, "An empty slice should not read")
+}
+
+func TestGenerator_FillV1(t *testing.T) {
+	calls := 0
+	now := Now()
+	gen := newGenerator(GeneratorConfig{
+		Next: func() Timestamp {
+			calls++
+			return now
+		},
+	})
+
+	ids := make([]ID, fillSize)
+	assert.NoError(t, gen.FillV1(ids))
+
+	seen := make(map[ID]bool, len(ids))
+	for i, id := range ids {
+		assert.Equal(t, One, id.Version())
+		when, _ := id.Time()
+		assert.Equal(t, now+Timestamp(i), when, "Timestamps should be consecutive")
+		seen[id] = true
+	}
+	assert.Len(t, seen, fillSize)
+	assert.Equal(t, now+fillSize-1, gen.Timestamp, "The block should be reserved")
+
+	after := gen.NewV1ID()
+	assert.False(t, seen[after], "Later UUIDs should not repeat the block")
+
+	v6 := make([]ID, fillSize)
+	assert.NoError(t, gen.FillV6(v6))
+	for i := 1; i < len(v6); i++ {
+		assert.Equal(t, Six, v6[i].Version())
+		assert.Equal(t, -1, Compare(v6[i-1], v6[i]), "V6 UUIDs should sort by time")
+	}
+	assert.Equal(t, 4, calls, "Next should be called once by init and once per call")
+}
+
+func TestGenerator_FillV7(t *testing.T) {
+	now := Now()
+	gen := newGenerator(GeneratorConfig{
+		Next: func() Timestamp {
+			now = now.Add(time.Millisecond)
+			return now
+		},
+		V7Mode: V7Counter,
+	})
+
+	ids := make([]ID, fillSize)
+	assert.NoError(t, gen.FillV7(ids))
+	for i := range ids {
+		assert.Equal(t, Seven, ids[i].Version())
+		assert.Equal(t, now.UnixMilli(), unixMilli(ids[i][:]))
+		if i > 0 {
+			assert.Equal(t, -1, Compare(ids[i-1], ids[i]), "V7 UUIDs should be in order")
+		}
+	}
+
+	last := ids[len(ids)-1]
+	assert.NoError(t, gen.FillV7(ids))
+	assert.Equal(t, -1, Compare(last, ids[0]), "Each block should follow the last")
+}
+
+func TestGenerator_Fill_Error(t *testing.T) {
+	handled := 0
+	failure := errors.New("no entropy")
+	gen := newGenerator(GeneratorConfig{
+		Id: func() Node {
+			return nil
+		},
+		Random: func([]byte) (int, error) {
+			return 0, failure
+		},
+		HandleError: func(error) bool {
+			handled++
+			return true
+		},
+	})
+
+	ids := []ID{ToID(NameSpaceDNS), ToID(NameSpaceURL)}
+	assert.Equal(t, failure, gen.FillV4(ids))
+	assert.Equal(t, []ID{{}, {}}, ids, "Should be cleared on error")
+
+	ids = []ID{ToID(NameSpaceDNS)}
+	assert.Equal(t, failure, gen.FillV7(ids))
+	assert.Equal(t, []ID{{}}, ids)
+	assert.Equal(t, 2, handled, "HandleError should be called once per call")
+
+	assert.Equal(t, failure, gen.FillV1(ids), "Should return the init error")
+	assert.Equal(t, failure, gen.FillV6(ids))
+
+	gen = newGenerator(GeneratorConfig{
+		Random: func([]byte) (int, error) {
+			return 0, failure
+		},
+	})
+	assert.NotPanics(t, func() {
+		assert.Equal(t, failure, gen.FillV4(ids), "The default HandleError should not panic")
+		assert.Equal(t, failure, gen.FillV7(ids))
+	})
+}
+
+func TestGenerator_Fill_Allocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("The race detector makes crypto/rand.Read allocate")
+	}
+	gen := newGenerator(GeneratorConfig{})
+	ids := make([]ID, fillSize)
+	assert.Equal(t, 0.0, testing.AllocsPerRun(10, func() { gen.FillV4(ids) }), "FillV4 should not allocate")
+	assert.Equal(t, 0.0, testing.AllocsPerRun(10, func() { gen.FillV7(ids) }), "FillV7 should not allocate")
+}
+
+func BenchmarkGenerator_NewV4ID(b *testing.B) {
+	gen := newGenerator(GeneratorConfig{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		gen.NewV4ID()
+	}
+}
+
+// Each op is a single UUID so the result compares with NewV4ID
+func BenchmarkGenerator_FillV4(b *testing.B) {
+	gen := newGenerator(GeneratorConfig{})
+	ids := make([]ID, fillSize)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i += fillSize {
+		gen.FillV4(ids)
+	}
+}
+
+func BenchmarkGenerator_NewV1ID(b *testing.B) {
+	gen := newGenerator(GeneratorConfig{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		gen.NewV1ID()
+	}
+}
+
+func BenchmarkGenerator_FillV1(b *testing.B) {
+	gen := newGenerator(GeneratorConfig{})
+	ids := make([]ID, fillSize)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i += fillSize {
+		gen.FillV1(ids)
+	}
+}
+
+func BenchmarkGenerator_NewV7ID(b *testing.B) {
+	gen := newGenerator(GeneratorConfig{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		gen.NewV7ID()
+	}
+}
+
+func BenchmarkGenerator_FillV7(b *testing.B) {
+	gen := newGenerator(GeneratorConfig{})
+	ids := make([]ID, fillSize)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i += fillSize {
+		gen.FillV7(ids)
+	}
+}
@@ -0,0 +1,180 @@
+package uuid
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// Encoding is a compact text encoding of the 128 bits of a UUID. Every
+// encoding has a fixed width so an encoded UUID always has the same length.
+// Base32, Base58 and Base62 treat the UUID as a big endian number and pad it
+// with leading zero digits, and as their alphabets are in ASCII order the
+// encoded strings sort in the same order as the UUIDs. This keeps time ordered
+// versions, such as V6 and V7, sortable as text.
+//
+// Base64URL is the unpadded URL safe encoding of RFC4648 so that it matches
+// encoding/base64. Its alphabet is not in ASCII order and so it does not
+// preserve the sort order of UUIDs.
+//
+// An Encoding can be used directly or through its Format constant with
+// Formatter, SwitchFormat, CompileFormat and NewPrinter. Encoded text is only
+// decoded when the Encoding is given, through ParseEncoded, DecodeString or a
+// Printer, as Base58 and Base62 text cannot be told apart. For the same reason
+// SwitchFormat to an encoded Format only changes printed text and MarshalText
+// keeps the canonical format; only Printer.Wrap marshals encoded text.
+type Encoding struct {
+	alphabet string
+	width    int
+	decode   [256]byte
+	base64   bool
+}
+
+// Encodings with their Format constants.
+var (
+	// Base32 uses the Crockford alphabet. Decoding is case insensitive and
+	// accepts I and L as 1 and O as 0.
+	Base32 = newEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ", 26).crockford()
+
+	// Base58 uses the Bitcoin alphabet which has no 0, O, I or l.
+	Base58 = newEncoding("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz", 22)
+
+	// Base62 uses the digits followed by the upper and then lower case letters.
+	Base62 = newEncoding("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz", 22)
+
+	// Base64URL is the unpadded URL safe base64 encoding.
+	Base64URL = newEncoding("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_", 22)
+)
+
+const (
+	FormatBase32    Format = "base32"
+	FormatBase58    Format = "base58"
+	FormatBase62    Format = "base62"
+	FormatBase64URL Format = "base64url"
+)
+
+var encodings = map[Format]*Encoding{
+	FormatBase32:    Base32,
+	FormatBase58:    Base58,
+	FormatBase62:    Base62,
+	FormatBase64URL: Base64URL,
+}
+
+const invalidDigit = 0xff
+
+var base64URL = base64.RawURLEncoding.Strict()
+
+func newEncoding(pAlphabet string, pWidth int) *Encoding {
+	o := &Encoding{alphabet: pAlphabet, width: pWidth, base64: len(pAlphabet) == 64}
+	for i := range o.decode {
+		o.decode[i] = invalidDigit
+	}
+	for i := 0; i < len(pAlphabet); i++ {
+		o.decode[pAlphabet[i]] = byte(i)
+	}
+	return o
+}
+
+// crockford makes decoding case insensitive and decodes I and L as 1 and O as
+// 0 as in Crockford's base32.
+func (o *Encoding) crockford() *Encoding {
+	for i := 0; i < len(o.alphabet); i++ {
+		o.decode[toLower(o.alphabet[i])] = byte(i)
+	}
+	o.decode['I'], o.decode['i'] = 1, 1
+	o.decode['L'], o.decode['l'] = 1, 1
+	o.decode['O'], o.decode['o'] = 0, 0
+	return o
+}
+
+// EncodedLen returns the length of an encoded UUID.
+func (o *Encoding) EncodedLen() int {
+	return o.width
+}
+
+// EncodeToString returns the encoded UUID. A nil or short UUID is padded with
+// zeros.
+func (o *Encoding) EncodeToString(pId UUID) string {
+	return string(o.AppendEncode(make([]byte, 0, o.width), pId))
+}
+
+// AppendEncode appends the encoded UUID to pDst and returns the extended
+// buffer.
+func (o *Encoding) AppendEncode(pDst []byte, pId UUID) []byte {
+	a := toArray(pId)
+	return o.appendEncode(pDst, &a)
+}
+
+func (o *Encoding) appendEncode(pDst []byte, pId *array) []byte {
+	if o.base64 {
+		return base64URL.AppendEncode(pDst, pId[:])
+	}
+	var buf [26]byte
+	base := uint64(len(o.alphabet))
+	hi, lo := binary.BigEndian.Uint64(pId[:8]), binary.BigEndian.Uint64(pId[8:])
+	for i := o.width - 1; i >= 0; i-- {
+		var r uint64
+		hi, r = bits.Div64(0, hi, base)
+		lo, r = bits.Div64(r, lo, base)
+		buf[i] = o.alphabet[r]
+	}
+	return append(pDst, buf[:o.width]...)
+}
+
+// ParseEncoded decodes a UUID encoded with the given Encoding. It is the same
+// as pEncoding.DecodeString.
+func ParseEncoded(pEncoding *Encoding, pUuid string) (Uuid, error) {
+	if pEncoding == nil {
+		return nil, errors.New("uuid.ParseEncoded: nil Encoding")
+	}
+	return pEncoding.DecodeString(pUuid)
+}
+
+// DecodeString decodes an encoded UUID. Errors are returned as a *ParseError.
+func (o *Encoding) DecodeString(pUuid string) (Uuid, error) {
+	id, err := o.decodeString(pUuid)
+	if err != nil {
+		return nil, err
+	}
+	return id[:], nil
+}
+
+func (o *Encoding) decodeString(pUuid string) (id array, err error) {
+	if len(pUuid) != o.width {
+		offset := len(pUuid)
+		if offset > o.width {
+			offset = o.width
+		}
+		return id, &ParseError{pUuid, offset, ReasonBadLength}
+	}
+	if o.base64 {
+		if _, err := base64URL.Decode(id[:], []byte(pUuid)); err != nil {
+			offset := 0
+			if corrupt, ok := err.(base64.CorruptInputError); ok {
+				offset = int(corrupt)
+			}
+			return array{}, &ParseError{pUuid, offset, ReasonBadDigit}
+		}
+		return id, nil
+	}
+	base := uint64(len(o.alphabet))
+	var hi, lo uint64
+	for i := 0; i < len(pUuid); i++ {
+		d := o.decode[pUuid[i]]
+		if d == invalidDigit {
+			return id, &ParseError{pUuid, i, ReasonBadDigit}
+		}
+		carry, l := bits.Mul64(lo, base)
+		l, c := bits.Add64(l, uint64(d), 0)
+		overflow, h := bits.Mul64(hi, base)
+		h, c = bits.Add64(h, carry+c, 0)
+		if overflow|c != 0 {
+			return id, &ParseError{pUuid, 0, ReasonOverflow}
+		}
+		hi, lo = h, l
+	}
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id, nil
+}
@@ -0,0 +1,166 @@
+package uuid
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+	"time"
+)
+
+var encodingTests = []struct {
+	encoding *Encoding
+	format   Format
+	dns      string
+	max      string
+}{
+	{Base32, FormatBase32, "3BMYW117DD278R1D00R17X8C68", "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+	{Base58, FormatBase58, "EJ34kCVxxF9jHMKD4EgrAK", "YcVfxkQb6JRzqk5kF2tNLv"},
+	{Base62, FormatBase62, "3H8pGALtipnCnHud4zBiky", "7n42DGM5Tflk9n8mt7Fhc7"},
+	{Base64URL, FormatBase64URL, "a6e4EJ2tEdGAtADAT9QwyA", "_____________________w"},
+}
+
+func TestEncoding(t *testing.T) {
+	for _, v := range encodingTests {
+		assert.Equal(t, v.dns, v.encoding.EncodeToString(NameSpaceDNS), "Format %s", v.format)
+		assert.Equal(t, v.max, v.encoding.EncodeToString(Max), "Format %s", v.format)
+		assert.Equal(t, len(v.dns), v.encoding.EncodedLen())
+		assert.Equal(t, v.dns, Formatter(NameSpaceDNS, v.format))
+
+		id, err := v.encoding.DecodeString(v.dns)
+		assert.NoError(t, err)
+		assert.Equal(t, Uuid(NameSpaceDNS), id)
+
+		id, err = v.encoding.DecodeString(v.max)
+		assert.NoError(t, err)
+		assert.True(t, Equal(Max, id))
+
+		for i := 0; i < 100; i++ {
+			id := NewV4()
+			out, err := v.encoding.DecodeString(v.encoding.EncodeToString(id))
+			assert.NoError(t, err)
+			assert.Equal(t, id, out, "Format %s should round trip", v.format)
+		}
+	}
+
+	id, err := Base32.DecodeString("3bmyw1i7dd278r1doorl7x8c68")
+	assert.NoError(t, err)
+	assert.Equal(t, Uuid(NameSpaceDNS), id, "Base32 should decode Crockford aliases")
+}
+
+func TestEncoding_Errors(t *testing.T) {
+	tests := []struct {
+		encoding *Encoding
+		input    string
+		offset   int
+		reason   ParseErrorReason
+	}{
+		{Base58, "EJ34kCVxxF9jHMKD4Egr", 20, ReasonBadLength},
+		{Base58, "EJ34kCVxxF9jHMKD4EgrAKK", 22, ReasonBadLength},
+		{Base58, "EJ34kCVxxF0jHMKD4EgrAK", 10, ReasonBadDigit},
+		{Base62, "3H8pGALtipnCnHud4zBik-", 21, ReasonBadDigit},
+		{Base62, "ZZZZZZZZZZZZZZZZZZZZZZ", 0, ReasonOverflow},
+		{Base32, "8ZZZZZZZZZZZZZZZZZZZZZZZZZ", 0, ReasonOverflow},
+		{Base32, "3BMYW117DD278R1D00R17X8CU8", 24, ReasonBadDigit},
+		{Base64URL, "a6e4EJ2tEdGAtADAT9Qwy+", 21, ReasonBadDigit},
+		{Base64URL, "a6e4EJ2tEdGAtADAT9QwyB", 20, ReasonBadDigit},
+	}
+	for _, v := range tests {
+		_, err := v.encoding.DecodeString(v.input)
+		var parseErr *ParseError
+		if !assert.True(t, errors.As(err, &parseErr), "Should return a ParseError for %s", v.input) {
+			continue
+		}
+		assert.Equal(t, v.offset, parseErr.Offset, "Offset for %s", v.input)
+		assert.Equal(t, v.reason, parseErr.Reason, "Reason for %s", v.input)
+		assert.True(t, errors.Is(err, ErrInvalidFormat))
+	}
+}
+
+func TestEncoding_Sorted(t *testing.T) {
+	now := Now()
+	gen := newGenerator(GeneratorConfig{
+		Next: func() Timestamp {
+			now = now.Add(time.Millisecond)
+			return now
+		},
+	})
+
+	for _, encoding := range []*Encoding{Base32, Base58, Base62} {
+		ids := make([]string, 100)
+		for i := range ids {
+			ids[i] = encoding.EncodeToString(gen.NewV7())
+		}
+		assert.True(t, sort.StringsAreSorted(ids), "Encoded V7 UUIDs should sort by time")
+	}
+}
+
+func TestParseEncoded(t *testing.T) {
+	defer SwitchFormat(FormatCanonical)
+	SwitchFormat(FormatBase58)
+
+	text := Base62.EncodeToString(NameSpaceDNS)
+	var id Uuid
+	assert.Error(t, id.UnmarshalText([]byte(text)), "Base62 text should not be read as the global Base58")
+
+	id, err := ParseEncoded(Base62, text)
+	assert.NoError(t, err)
+	assert.Equal(t, Uuid(NameSpaceDNS), id)
+
+	_, err = ParseEncoded(nil, text)
+	assert.Error(t, err)
+}
+
+func TestEncoding_Format(t *testing.T) {
+	defer SwitchFormat(FormatCanonical)
+
+	for _, v := range encodingTests {
+		SwitchFormat(v.format)
+		assert.Equal(t, v.dns, NameSpaceDNS.String())
+
+		b, err := Uuid(NameSpaceDNS).MarshalText()
+		assert.NoError(t, err)
+		assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", string(b), "MarshalText should keep hex, only String is encoded")
+
+		var id Uuid
+		assert.NoError(t, id.UnmarshalText(b))
+		assert.Equal(t, Uuid(NameSpaceDNS), id)
+		assert.Error(t, id.UnmarshalText([]byte(v.dns)), "UnmarshalText should not decode")
+
+		var fixed ID
+		assert.NoError(t, fixed.UnmarshalText(b))
+		assert.Equal(t, ToID(NameSpaceDNS), fixed)
+		assert.Error(t, fixed.UnmarshalText([]byte(v.dns)))
+
+		decoded, err := ParseEncoded(encodings[v.format], v.dns)
+		assert.NoError(t, err)
+		assert.Equal(t, Uuid(NameSpaceDNS), decoded)
+
+		var url Uuid
+		assert.NoError(t, url.UnmarshalText([]byte(Formatter(NameSpaceURL, FormatCanonical))), "Should still accept Parse formats")
+
+		p, err := NewPrinter(v.format)
+		assert.NoError(t, err)
+		f := p.Wrap(NameSpaceURL)
+		b, err = f.MarshalText()
+		assert.NoError(t, err)
+		assert.Equal(t, NameSpaceURL.String(), string(b), "Printer.Wrap should marshal encoded text")
+		assert.NoError(t, f.Scan(f.String()))
+		assert.Equal(t, ToID(NameSpaceURL), f.ID)
+	}
+}
+
+func BenchmarkBase58_EncodeToString(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Base58.EncodeToString(NameSpaceDNS)
+	}
+}
+
+func BenchmarkBase58_DecodeString(b *testing.B) {
+	s := Base58.EncodeToString(NameSpaceDNS)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Base58.DecodeString(s)
+	}
+}
@@ -0,0 +1,67 @@
+package uuid
+
+import "fmt"
+
+// ParseErrorReason describes why a string could not be parsed as a UUID.
+type ParseErrorReason uint8
+
+const (
+	ReasonBadLength          ParseErrorReason = iota + 1 // Too few or too many digits
+	ReasonBadHexDigit                                    // A character which is not a hex digit
+	ReasonMisplacedHyphen                                // A hyphen missing from or outside of its canonical place
+	ReasonMismatchedBrackets                             // An opening bracket without its closing bracket or vice versa
+	ReasonBadURNPrefix                                   // A URN prefix other than urn:uuid:
+	ReasonBadDigit                                       // A character outside the alphabet of an Encoding
+	ReasonOverflow                                       // An encoded value which does not fit in 128 bits
+	ReasonBadPrefix                                      // A TypedID prefix which is invalid or not the expected prefix
+)
+
+// String returns an English description of the reason.
+func (o ParseErrorReason) String() string {
+	switch o {
+	case ReasonBadLength:
+		return "bad length"
+	case ReasonBadHexDigit:
+		return "bad hex digit"
+	case ReasonMisplacedHyphen:
+		return "misplaced hyphen"
+	case ReasonMismatchedBrackets:
+		return "mismatched brackets"
+	case ReasonBadURNPrefix:
+		return "bad URN prefix"
+	case ReasonBadDigit:
+		return "bad digit"
+	case ReasonOverflow:
+		return "overflow"
+	case ReasonBadPrefix:
+		return "bad prefix"
+	default:
+		return "unknown reason"
+	}
+}
+
+var _ error = &ParseError{}
+
+// ParseError is returned by Parse, UnmarshalText and Scan when a string is not
+// a valid UUID representation. Use errors.As to retrieve it. It wraps
+// ErrInvalidFormat so errors.Is can also be used.
+type ParseError struct {
+	// Input is the string which was being parsed
+	Input string
+
+	// Offset is the byte offset of the first invalid character in Input
+	Offset int
+
+	// Reason describes why the character is invalid
+	Reason ParseErrorReason
+}
+
+// Error implements the error interface.
+func (o *ParseError) Error() string {
+	return fmt.Sprintf("uuid.Parse: invalid string format %s at offset %d in %q", o.Reason, o.Offset, o.Input)
+}
+
+// Unwrap returns ErrInvalidFormat.
+func (o *ParseError) Unwrap() error {
+	return ErrInvalidFormat
+}
@@ -0,0 +1,166 @@
+package uuid
+
+import (
+	"encoding/binary"
+)
+
+// The field accessors decode the fields of time based UUIDs. Each returns
+// false when the UUID is not of the RFC4122 variant or its version does not
+// have the field.
+//
+//	Version  Time  ClockSequence  NodeID  Domain  ID
+//	1        yes   14 bits        yes
+//	2        yes*  6 bits         yes     yes     yes
+//	6        yes   14 bits        yes
+//	7        yes
+//
+// * V2 UUIDs replace the low 32 bits of the timestamp with the POSIX UID or
+// GID so the Time is only accurate to about 7 minutes. They also replace the
+// low 8 bits of the clock sequence with the Domain. The missing bits are
+// returned as zeros.
+
+// Time returns the Timestamp of a V1, V2, V6 or V7 Uuid. Use Timestamp.Time to
+// get a time.Time.
+func (o Uuid) Time() (Timestamp, bool) {
+	a := toArray(o)
+	return a.time()
+}
+
+// ClockSequence returns the clock sequence of a V1, V2 or V6 Uuid.
+func (o Uuid) ClockSequence() (Sequence, bool) {
+	a := toArray(o)
+	return a.clockSequence()
+}
+
+// NodeID returns a copy of the node of a V1, V2 or V6 Uuid.
+func (o Uuid) NodeID() (Node, bool) {
+	a := toArray(o)
+	return a.node()
+}
+
+// Domain returns the Domain of a V2 Uuid.
+func (o Uuid) Domain() (Domain, bool) {
+	a := toArray(o)
+	return a.domain()
+}
+
+// ID returns the POSIX UID or GID of a V2 Uuid, as given by its Domain.
+func (o Uuid) ID() (uint32, bool) {
+	a := toArray(o)
+	return a.localID()
+}
+
+// Time returns the Timestamp of a V1, V2, V6 or V7 Immutable.
+func (o Immutable) Time() (Timestamp, bool) {
+	a := toArray(o)
+	return a.time()
+}
+
+// ClockSequence returns the clock sequence of a V1, V2 or V6 Immutable.
+func (o Immutable) ClockSequence() (Sequence, bool) {
+	a := toArray(o)
+	return a.clockSequence()
+}
+
+// NodeID returns a copy of the node of a V1, V2 or V6 Immutable.
+func (o Immutable) NodeID() (Node, bool) {
+	a := toArray(o)
+	return a.node()
+}
+
+// Domain returns the Domain of a V2 Immutable.
+func (o Immutable) Domain() (Domain, bool) {
+	a := toArray(o)
+	return a.domain()
+}
+
+// ID returns the POSIX UID or GID of a V2 Immutable, as given by its Domain.
+func (o Immutable) ID() (uint32, bool) {
+	a := toArray(o)
+	return a.localID()
+}
+
+// Time returns the Timestamp of a V1, V2, V6 or V7 ID.
+func (o ID) Time() (Timestamp, bool) {
+	a := array(o)
+	return a.time()
+}
+
+// ClockSequence returns the clock sequence of a V1, V2 or V6 ID.
+func (o ID) ClockSequence() (Sequence, bool) {
+	a := array(o)
+	return a.clockSequence()
+}
+
+// NodeID returns a copy of the node of a V1, V2 or V6 ID.
+func (o ID) NodeID() (Node, bool) {
+	a := array(o)
+	return a.node()
+}
+
+// Domain returns the Domain of a V2 ID.
+func (o ID) Domain() (Domain, bool) {
+	a := array(o)
+	return a.domain()
+}
+
+// ID returns the POSIX UID or GID of a V2 ID, as given by its Domain.
+func (o ID) ID() (uint32, bool) {
+	a := array(o)
+	return a.localID()
+}
+
+// Returns the version of an RFC4122 variant UUID and Unknown for any other
+// variant.
+func (o *array) rfcVersion() Version {
+	if variant(o[variantIndex]) != VariantRFC4122 {
+		return Unknown
+	}
+	return resolveVersion(o[versionIndex] >> 4)
+}
+
+func (o *array) time() (Timestamp, bool) {
+	switch o.rfcVersion() {
+	case One:
+		return timestampV1(o[:]), true
+	case Two:
+		return timestampV1(o[:]) &^ 0xffffffff, true
+	case Six:
+		return timestampV6(o[:]), true
+	case Seven:
+		return Timestamp(unixMilli(o[:])*1e4 + gregorianToUNIXOffset), true
+	}
+	return 0, false
+}
+
+func (o *array) clockSequence() (Sequence, bool) {
+	switch o.rfcVersion() {
+	case One, Six:
+		return Sequence(o[8]&0x3f)<<8 | Sequence(o[9]), true
+	case Two:
+		return Sequence(o[8]&0x3f) << 8, true
+	}
+	return 0, false
+}
+
+func (o *array) node() (Node, bool) {
+	switch o.rfcVersion() {
+	case One, Two, Six:
+		return Node(append([]byte(nil), o[10:]...)), true
+	}
+	return nil, false
+}
+
+func (o *array) domain() (Domain, bool) {
+	if o.rfcVersion() != Two {
+		return 0, false
+	}
+	return Domain(o[9]), true
+}
+
+func (o *array) localID() (uint32, bool) {
+	if o.rfcVersion() != Two {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(o[:4]), true
+}
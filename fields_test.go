@@ -0,0 +1,105 @@
+package uuid
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+	"time"
+)
+
+// Vectors from RFC9562 Appendix A
+func TestUuid_Time(t *testing.T) {
+	expect := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+
+	for _, v := range []string{
+		"c232ab00-9414-11ec-b3c8-9f6bdeced846",
+		"1ec9414c-232a-6b00-b3c8-9f6bdeced846",
+		"017f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+	} {
+		id, _ := Parse(v)
+		now, ok := id.Time()
+		assert.True(t, ok, "Should have a time %s", v)
+		assert.Equal(t, expect, now.Time(), "Time of %s", v)
+
+		now, ok = ToID(id).Time()
+		assert.True(t, ok)
+		assert.Equal(t, expect, now.Time())
+
+		now, ok = Immutable(id).Time()
+		assert.True(t, ok)
+		assert.Equal(t, expect, now.Time())
+	}
+
+	for _, v := range []UUID{Nil, Max, NewV4(), NewV5(NameSpaceDNS, Name("a")), Uuid{1, 2}} {
+		_, ok := ToID(v).Time()
+		assert.False(t, ok, "Should have no time %s", v)
+	}
+}
+
+func TestUuid_Fields(t *testing.T) {
+	for _, v := range []string{
+		"c232ab00-9414-11ec-b3c8-9f6bdeced846",
+		"1ec9414c-232a-6b00-b3c8-9f6bdeced846",
+	} {
+		id, _ := Parse(v)
+
+		seq, ok := id.ClockSequence()
+		assert.True(t, ok)
+		assert.Equal(t, Sequence(0x33c8), seq)
+
+		node, ok := id.NodeID()
+		assert.True(t, ok)
+		assert.Equal(t, Node{0x9f, 0x6b, 0xde, 0xce, 0xd8, 0x46}, node)
+
+		node[0] = 0
+		assert.Equal(t, v, id.String(), "NodeID should return a copy")
+
+		_, ok = id.Domain()
+		assert.False(t, ok)
+		_, ok = id.ID()
+		assert.False(t, ok)
+	}
+
+	v7, _ := Parse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	_, ok := v7.ClockSequence()
+	assert.False(t, ok)
+	_, ok = v7.NodeID()
+	assert.False(t, ok)
+}
+
+func TestUuid_FieldsV2(t *testing.T) {
+	now := Now()
+	gen := newGenerator(GeneratorConfig{
+		Next: func() Timestamp {
+			return now
+		},
+	})
+
+	for domain, local := range map[Domain]uint32{DomainUser: uint32(os.Getuid()), DomainGroup: uint32(os.Getgid())} {
+		id := gen.NewV2(domain)
+
+		d, ok := id.Domain()
+		assert.True(t, ok)
+		assert.Equal(t, domain, d)
+
+		l, ok := id.ID()
+		assert.True(t, ok)
+		assert.Equal(t, local, l)
+
+		l, ok = ToID(id).ID()
+		assert.True(t, ok)
+		assert.Equal(t, local, l)
+
+		when, ok := id.Time()
+		assert.True(t, ok)
+		assert.Equal(t, gen.Timestamp&^0xffffffff, when, "Only the high bits of the time are kept")
+
+		seq, ok := id.ClockSequence()
+		assert.True(t, ok)
+		assert.Equal(t, gen.Sequence&0x3f00, seq, "Only the high bits of the sequence are kept")
+
+		node, ok := id.NodeID()
+		assert.True(t, ok)
+		assert.Equal(t, Node(id[10:]), node)
+	}
+}
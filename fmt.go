@@ -0,0 +1,91 @@
+package uuid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var hexUpperFormat, _ = CompileFormat("%X%X%X%X%X")
+
+var (
+	_ fmt.Formatter = Uuid{}
+	_ fmt.Formatter = Immutable("")
+	_ fmt.Formatter = ID{}
+)
+
+// Format implements the fmt.Formatter interface.
+//
+//	%s, %v  the current uuid.Format as set by uuid.SwitchFormat
+//	%q      the current uuid.Format double quoted
+//	%x, %X  32 lower or upper case hex digits
+//	%+v     the canonical form followed by the version, variant and, for
+//	        time based versions, the time, clock sequence and node
+//
+// Width and flags are applied to the whole UUID as if it were a string.
+func (o Uuid) Format(pState fmt.State, pVerb rune) {
+	formatVerb(pState, pVerb, o, "Uuid")
+}
+
+// Format implements the fmt.Formatter interface in the same way as
+// Uuid.Format.
+func (o Immutable) Format(pState fmt.State, pVerb rune) {
+	formatVerb(pState, pVerb, Uuid(o), "Immutable")
+}
+
+// Format implements the fmt.Formatter interface in the same way as
+// Uuid.Format.
+func (o ID) Format(pState fmt.State, pVerb rune) {
+	formatVerb(pState, pVerb, o[:], "ID")
+}
+
+func formatVerb(pState fmt.State, pVerb rune, pId Uuid, pType string) {
+	var s string
+	switch pVerb {
+	case 'v':
+		if pState.Flag('+') {
+			s = annotate(pId)
+		} else {
+			s = pId.String()
+		}
+	case 's':
+		s = pId.String()
+	case 'q':
+		s = strconv.Quote(pId.String())
+	case 'x':
+		s = formatUuid(pId, FormatHex)
+	case 'X':
+		s = hexUpperFormat.sprint(pId)
+	default:
+		fmt.Fprintf(pState, "%%!%c(uuid.%s=%s)", pVerb, pType, pId.String())
+		return
+	}
+	fmt.Fprintf(pState, fmt.FormatString(pState, 's'), s)
+}
+
+// annotate returns the canonical form of the UUID followed by a breakdown of
+// its fields.
+func annotate(pId Uuid) string {
+	a := toArray(pId)
+	b := a[:]
+
+	s := new(strings.Builder)
+	s.Write(canonicalFormat.appendUuid(nil, b))
+	fmt.Fprintf(s, "{Version:%d Variant:%s", pId.Version(), variantName(pId.Variant()))
+
+	switch a.rfcVersion() {
+	case One, Six:
+		now, _ := a.time()
+		seq, _ := a.clockSequence()
+		fmt.Fprintf(s, " Time:%s Sequence:%d Node:%x", now, seq, b[10:])
+	case Two:
+		domain, _ := a.domain()
+		id, _ := a.localID()
+		fmt.Fprintf(s, " Domain:%d Id:%d Node:%x", domain, id, b[10:])
+	case Seven:
+		fmt.Fprintf(s, " Time:%s", time.UnixMilli(int64(unixMilli(b))).UTC())
+	}
+	s.WriteByte('}')
+	return s.String()
+}
@@ -0,0 +1,39 @@
+package uuid
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUuid_Format(t *testing.T) {
+	id := NameSpaceDNS
+	ids := []UUID{Uuid(id), id, ToID(id)}
+
+	for _, v := range ids {
+		assert.Equal(t, "6ba7b8109dad11d180b400c04fd430c8", fmt.Sprintf("%x", v))
+		assert.Equal(t, "6BA7B8109DAD11D180B400C04FD430C8", fmt.Sprintf("%X", v))
+		assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", fmt.Sprintf("%s", v))
+		assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", fmt.Sprintf("%v", v))
+		assert.Equal(t, `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`, fmt.Sprintf("%q", v))
+		assert.Equal(t, "  6ba7b810-9dad-11d1-80b4-00c04fd430c8", fmt.Sprintf("%38s", v))
+		assert.Equal(t, "6ba7b8109dad11d180b400c04fd430c8  ", fmt.Sprintf("%-34x", v))
+		assert.Contains(t, fmt.Sprintf("%d", v), "%!d(uuid.")
+	}
+
+	assert.Equal(t,
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8{Version:1 Variant:RFC4122 Time:1998-02-04 22:13:53.1511824 +0000 UTC Sequence:180 Node:00c04fd430c8}",
+		fmt.Sprintf("%+v", id))
+
+	v7, _ := Parse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	assert.Equal(t,
+		"017f22e2-79b0-7cc3-98c4-dc0c0c07398f{Version:7 Variant:RFC4122 Time:2022-02-22 19:22:22 +0000 UTC}",
+		fmt.Sprintf("%+v", v7))
+
+	v4, _ := Parse("919108f7-52d1-4320-9bac-f847db4148a8")
+	assert.Equal(t, "919108f7-52d1-4320-9bac-f847db4148a8{Version:4 Variant:RFC4122}", fmt.Sprintf("%+v", v4))
+
+	SwitchFormat(FormatCanonicalCurly)
+	defer SwitchFormat(FormatCanonical)
+	assert.Equal(t, "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}", fmt.Sprintf("%v", id))
+}
@@ -2,7 +2,10 @@ package uuid
 
 import (
 	"errors"
+	"io"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 // Format represents different styles a UUID can be printed in constants
@@ -22,7 +25,9 @@ const (
 	FormatUrn              Format = "urn:uuid:" + FormatCanonical
 )
 
-var printFormat Format = FormatCanonical
+// printFormat holds the *CompiledFormat used by String and MarshalText. It is
+// atomic so that SwitchFormat can be called while UUIDs are being printed.
+var printFormat atomic.Value
 
 var defaultFormats map[Format]bool = make(map[Format]bool)
 
@@ -34,9 +39,51 @@ func init() {
 	defaultFormats[FormatCanonicalCurly] = true
 	defaultFormats[FormatCanonicalBracket] = true
 	defaultFormats[FormatUrn] = true
+
+	for f := range encodings {
+		defaultFormats[f] = true
+	}
+
+	for f := range defaultFormats {
+		compiledFormats[f], _ = CompileFormat(f)
+	}
+	compiledFormats[FormatCanonical] = canonicalFormat
+
+	printFormat.Store(canonicalFormat)
+}
+
+// compiledFormats caches the compiled default formats
+var compiledFormats = make(map[Format]*CompiledFormat)
+
+var canonicalFormat, _ = CompileFormat(FormatCanonical)
+
+// currentFormat returns the format set by SwitchFormat.
+func currentFormat() *CompiledFormat {
+	return printFormat.Load().(*CompiledFormat)
+}
+
+// NewFormat validates a custom format string and returns it as a Format. Use
+// it to check a Format before giving it to SwitchFormat or Formatter which
+// will panic on an invalid Format.
+//
+// A valid format will have 5 groups of [%x|%X] or follow the pattern,
+// *%[xX]*%[xX]*%[xX]*%[xX]*%[xX]*. Any extra uses of [%] outside of the
+// [%x|%X] are invalid.
+func NewFormat(pFormat string) (Format, error) {
+	if err := validateFormat(Format(pFormat)); err != nil {
+		return "", err
+	}
+	return Format(pFormat), nil
 }
 
-// SwitchFormat switches the default printing format for ALL UUIDs.
+// SwitchFormat switches the default printing format for ALL UUIDs. It is safe
+// to call concurrently with printing, however it affects every user of the
+// package in the process. Libraries should use a Printer instead.
+//
+// An encoded Format, such as FormatBase58, only changes printed text; String,
+// the fmt verbs and WriteTo. MarshalText, and so encoding/json and
+// database/sql, keep writing canonical hex so that the text can always be read
+// back. Use Printer.Wrap to marshal encoded text.
 //
 // The default is the canonical uuid.Format.FormatCanonical which has been
 // optimised for use with this package. It is twice as fast compared to other
@@ -48,11 +95,16 @@ func init() {
 // A valid format will have 5 groups of [%x|%X] or follow the pattern,
 // *%[xX]*%[xX]*%[xX]*%[xX]*%[xX]*. If the supplied format does not meet this
 // standard the function will panic. Note any extra uses of [%] outside of the
-// [%x|%X] will also cause a panic.
+// [%x|%X] will also cause a panic. Use uuid.NewFormat to validate a format
+// without panicking.
 // Constant uuid.Formats have been provided for the most likely formats.
 func SwitchFormat(pFormat Format) {
 	checkFormat(pFormat)
-	printFormat = pFormat
+	f, ok := compiledFormats[pFormat]
+	if !ok {
+		f, _ = CompileFormat(pFormat)
+	}
+	printFormat.Store(f)
 }
 
 // SwitchFormatToUpper is a convenience function to set the Format to uppercase
@@ -69,24 +121,35 @@ func SwitchFormatToUpper(pFormat Format) {
 // A valid format will have 5 groups of [%x|%X] or follow the pattern,
 // *%[xX]*%[xX]*%[xX]*%[xX]*%[xX]*. If the supplied format does not meet this
 // standard the function will panic. Note any extra uses of [%] outside of the
-// [%x|%X] will also cause a panic.
+// [%x|%X] will also cause a panic. Use uuid.NewFormat to validate a format
+// without panicking, or uuid.CompileFormat to render many UUIDs in the same
+// format.
 func Formatter(pId UUID, pFormat Format) string {
 	checkFormat(pFormat)
-	return formatUuid(pId.Bytes(), pFormat)
+	return formatUuid(uuidBytes(pId), pFormat)
 }
 
+var errInvalidFormat = errors.New("uuid.Format: invalid format")
+
 func checkFormat(pFormat Format) {
-	if defaultFormats[pFormat] {
-		return
+	if err := validateFormat(pFormat); err != nil {
+		panic(err)
+	}
+}
+
+func validateFormat(pFormat Format) error {
+	if defaultFormats[pFormat] || encodings[pFormat] != nil {
+		return nil
 	}
 	s := strings.ToLower(string(pFormat))
 	if strings.Count(s, "%x") != 5 {
-		panic(errors.New("uuid.Format: invalid format"))
+		return errInvalidFormat
 	}
 	s = strings.Replace(s, "%x", "", -1)
 	if strings.Count(s, "%") > 0 {
-		panic(errors.New("uuid.Format: invalid format"))
+		return errInvalidFormat
 	}
+	return nil
 }
 
 const (
@@ -100,65 +163,135 @@ const (
 
 var groups = [...]int{4, 2, 2, 2, 6}
 
+// formatUuid renders a UUID in an already validated Format.
 func formatUuid(pSrc []byte, pFormat Format) string {
-	if pFormat == FormatCanonical {
-		return string(formatCanonical(pSrc))
+	f, ok := compiledFormats[pFormat]
+	if !ok {
+		f, _ = CompileFormat(pFormat)
 	}
-	return string(format(pSrc, string(pFormat)))
+	return f.sprint(pSrc)
 }
 
-func format(pSrc []byte, pFormat string) []byte {
-	end := len(pFormat)
-	buf := make([]byte, end+uuidStringBufferSize)
+// CompiledFormat is a validated Format split into the literal text around
+// each of its five hex groups, or the Encoding of an encoded Format. It
+// renders UUIDs without scanning the Format again and, through AppendID,
+// AppendUuid and Fprint, without allocating. A CompiledFormat is immutable and
+// safe for concurrent use.
+type CompiledFormat struct {
+	format   Format
+	literals [len(groups) + 1]string
+	upper    [len(groups)]bool
+	size     int
+	encoding *Encoding
+}
 
-	var s, ls, b, e, p int
-	var u bool
-	for _, v := range groups {
-		ls = s
-		for ; s < end && pFormat[s] != '%'; s++ {
-		}
-		copy(buf[p:], pFormat[ls:s])
-		p += s - ls
-		s++
-		u = pFormat[s] == 'X'
-		s++
-		e = b + v
-		for i, t := range pSrc[b:e] {
-			j := p + i + i
-			table := hexTable
-			if u {
-				table = hexUpperTable
-			}
-			buf[j] = table[t>>4]
-			buf[j+1] = table[t&0x0f]
-		}
-		b = e
-		p += v + v
+// CompileFormat validates the Format and compiles it for repeated use.
+func CompileFormat(pFormat Format) (*CompiledFormat, error) {
+	if err := validateFormat(pFormat); err != nil {
+		return nil, err
+	}
+	if e := encodings[pFormat]; e != nil {
+		return &CompiledFormat{format: pFormat, size: e.width, encoding: e}, nil
 	}
-	ls = s
-	for ; s < end && pFormat[s] != '%'; s++ {
+	o := &CompiledFormat{format: pFormat, size: len(pFormat) + uuidStringBufferSize}
+	s := string(pFormat)
+	for i := range o.upper {
+		j := strings.IndexByte(s, '%')
+		o.literals[i] = s[:j]
+		o.upper[i] = s[j+1] == 'X'
+		s = s[j+2:]
 	}
-	copy(buf[p:], pFormat[ls:s])
-	p += s - ls
-	return buf
-}
-
-func formatCanonical(pSrc []byte) []byte {
-	buf := make([]byte, canonicalLength)
-	var b, p, e int
-	for h, v := range groups {
-		e = b + v
-		for i, t := range pSrc[b:e] {
-			j := p + i + i
-			buf[j] = hexTable[t>>4]
-			buf[j+1] = hexTable[t&0x0f]
+	o.literals[len(groups)] = s
+	return o, nil
+}
+
+// String returns the Format which was compiled.
+func (o *CompiledFormat) String() string {
+	return string(o.format)
+}
+
+// Size returns the length in bytes of a UUID rendered in the format.
+func (o *CompiledFormat) Size() int {
+	return o.size
+}
+
+// AppendTo appends the UUID rendered in the format to pDst and returns the
+// extended buffer. Converting an ID or Uuid variable to the UUID interface may
+// allocate, use AppendID or AppendUuid in hot paths.
+func (o *CompiledFormat) AppendTo(pDst []byte, pId UUID) []byte {
+	a := toArray(pId)
+	return o.appendUuid(pDst, a[:])
+}
+
+// AppendID appends the ID rendered in the format to pDst and returns the
+// extended buffer. It does not allocate if pDst has enough capacity.
+func (o *CompiledFormat) AppendID(pDst []byte, pId ID) []byte {
+	return o.appendUuid(pDst, pId[:])
+}
+
+// AppendUuid is the same as AppendID for a Uuid. A short Uuid is padded with
+// zeros.
+func (o *CompiledFormat) AppendUuid(pDst []byte, pId Uuid) []byte {
+	return o.appendUuid(pDst, pId)
+}
+
+// Fprint writes the UUID rendered in the format to pWriter. It returns the
+// number of bytes written and any write error. It does not allocate other than
+// to convert the UUID to the interface.
+func (o *CompiledFormat) Fprint(pWriter io.Writer, pId UUID) (int, error) {
+	buf := formatBuffers.Get().(*[]byte)
+	*buf = o.AppendTo((*buf)[:0], pId)
+	n, err := pWriter.Write(*buf)
+	formatBuffers.Put(buf)
+	return n, err
+}
+
+// Sprint returns the UUID rendered in the format.
+func (o *CompiledFormat) Sprint(pId UUID) string {
+	a := toArray(pId)
+	return o.sprint(a[:])
+}
+
+var formatBuffers = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, len(FormatUrn)+uuidStringBufferSize)
+		return &b
+	},
+}
+
+// appendUuid appends the formatted UUID to pDst. A short UUID is padded with
+// zeros.
+func (o *CompiledFormat) appendUuid(pDst []byte, pSrc []byte) []byte {
+	a := array{}
+	a.unmarshal(pSrc)
+	if o.encoding != nil {
+		return o.encoding.appendEncode(pDst, &a)
+	}
+	pSrc = a[:]
+	var b int
+	for i, v := range groups {
+		pDst = append(pDst, o.literals[i]...)
+		table := hexTable
+		if o.upper[i] {
+			table = hexUpperTable
 		}
-		b = e
-		p += v + v
-		if h < 4 {
-			buf[p] = '-'
-			p += 1
+		for _, t := range pSrc[b : b+v] {
+			pDst = append(pDst, table[t>>4], table[t&0x0f])
 		}
+		b += v
+	}
+	return append(pDst, o.literals[len(groups)]...)
+}
+
+// parse decodes text in the format's Encoding and otherwise in any format
+// accepted by Parse.
+func (o *CompiledFormat) parse(pUuid string) (array, error) {
+	if o.encoding != nil && len(pUuid) == o.encoding.width {
+		return o.encoding.decodeString(pUuid)
 	}
-	return buf
+	return parse(pUuid)
+}
+
+func (o *CompiledFormat) sprint(pSrc []byte) string {
+	return string(o.appendUuid(make([]byte, 0, o.size), pSrc))
 }
@@ -0,0 +1,133 @@
+package uuid
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewFormat(t *testing.T) {
+	valid := []string{
+		string(FormatCanonical),
+		string(FormatUrn),
+		"%X-%x-%X-%x-%X",
+		"id:%x%x%x%x%x;",
+	}
+	for _, v := range valid {
+		f, err := NewFormat(v)
+		assert.NoError(t, err, "Should be a valid format %s", v)
+		assert.Equal(t, Format(v), f)
+		assert.NotPanics(t, func() {
+			Formatter(NameSpaceDNS, f)
+		})
+	}
+
+	invalid := []string{
+		"",
+		"%x-%x-%x-%x",
+		"%x-%x-%x-%x-%x-%x",
+		"%x-%x-%x-%x-%x%",
+		"%d-%x-%x-%x-%x",
+	}
+	for _, v := range invalid {
+		_, err := NewFormat(v)
+		assert.Error(t, err, "Should be an invalid format %s", v)
+		assert.Panics(t, func() {
+			Formatter(NameSpaceDNS, Format(v))
+		})
+	}
+}
+
+func TestCompileFormat(t *testing.T) {
+	for _, v := range []Format{FormatCanonical, FormatUrn, FormatCanonicalCurly, "id:%X%x%X%x%X;"} {
+		f, err := CompileFormat(v)
+		if !assert.NoError(t, err, "Should compile %s", v) {
+			continue
+		}
+		expect := Formatter(NameSpaceDNS, v)
+		assert.Equal(t, string(v), f.String())
+		assert.Equal(t, expect, f.Sprint(NameSpaceDNS))
+		assert.Equal(t, len(expect), f.Size())
+		assert.Equal(t, "x:"+expect, string(f.AppendTo([]byte("x:"), NameSpaceDNS)))
+
+		b := new(bytes.Buffer)
+		n, err := f.Fprint(b, NameSpaceDNS)
+		assert.NoError(t, err)
+		assert.Equal(t, len(expect), n)
+		assert.Equal(t, expect, b.String())
+	}
+
+	_, err := CompileFormat("%x-%x")
+	assert.Error(t, err)
+}
+
+func TestCompiledFormat_Append_Allocs(t *testing.T) {
+	f, _ := CompileFormat(FormatUrn)
+	id := ToID(NameSpaceDNS)
+	u := Uuid(NameSpaceDNS)
+	buf := make([]byte, 0, f.Size())
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = f.AppendID(buf[:0], id)
+	})
+	assert.Equal(t, 0.0, allocs, "AppendID should not allocate")
+	assert.Equal(t, f.Sprint(id), string(buf))
+
+	allocs = testing.AllocsPerRun(100, func() {
+		buf = f.AppendUuid(buf[:0], u)
+	})
+	assert.Equal(t, 0.0, allocs, "AppendUuid should not allocate")
+	assert.Equal(t, f.Sprint(u), string(buf))
+
+	p, _ := NewPrinter(FormatUrn)
+	allocs = testing.AllocsPerRun(100, func() {
+		buf = p.AppendID(buf[:0], id)
+	})
+	assert.Equal(t, 0.0, allocs, "Printer.AppendID should not allocate")
+
+	// A constant Immutable is converted to the UUID interface without
+	// allocating
+	allocs = testing.AllocsPerRun(100, func() {
+		f.Fprint(&discard{}, NameSpaceDNS)
+	})
+	assert.Equal(t, 0.0, allocs, "Fprint should not allocate")
+}
+
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) { return len(p), nil }
+
+func BenchmarkFormatter(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Formatter(NameSpaceDNS, FormatUrn)
+	}
+}
+
+func BenchmarkCompiledFormat_Sprint(b *testing.B) {
+	f, _ := CompileFormat(FormatUrn)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f.Sprint(NameSpaceDNS)
+	}
+}
+
+func BenchmarkCompiledFormat_AppendTo(b *testing.B) {
+	f, _ := CompileFormat(FormatUrn)
+	id := ToID(NameSpaceDNS)
+	buf := make([]byte, 0, f.Size())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = f.AppendTo(buf[:0], id)
+	}
+}
+
+func BenchmarkCompiledFormat_AppendID(b *testing.B) {
+	f, _ := CompileFormat(FormatUrn)
+	id := ToID(NameSpaceDNS)
+	buf := make([]byte, 0, f.Size())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = f.AppendID(buf[:0], id)
+	}
+}
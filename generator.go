@@ -4,17 +4,36 @@ import (
 	"bytes"
 	"crypto/rand"
 	"encoding/binary"
-	"log"
+	"errors"
+	"io"
+	"log/slog"
 	"net"
 	"os"
+	"reflect"
 	"sync"
+	"sync/atomic"
 )
 
 var (
-	once      *sync.Once = new(sync.Once)
-	generator *Generator = newGenerator(GeneratorConfig{})
+	// generator is the default package Generator. It is atomic so that it
+	// can be replaced by RegisterGenerator and ResetDefault while in use.
+	generator atomic.Pointer[Generator]
+
+	// registered is set once RegisterGenerator or Init has been called
+	registered atomic.Bool
 )
 
+// discardLogger is the default Logger which drops every record
+var discardLogger = slog.New(slog.DiscardHandler)
+
+func init() {
+	generator.Store(newGenerator(GeneratorConfig{}))
+}
+
+// ErrGeneratorRegistered is returned by RegisterGenerator and Init when the
+// default Generator has already been registered.
+var ErrGeneratorRegistered = errors.New("uuid.RegisterGenerator: a uuid.Register* method cannot be called more than once")
+
 // Random provides a CPRNG which reads into the given []byte, the package
 // uses crypto/rand.Read by default. You can supply your own CPRNG. The
 // function is used by V4 UUIDs and for setting up V1 and V2 UUIDs via the
@@ -46,6 +65,46 @@ type Id func() Node
 // from your standalone Generator.
 type HandleError func(error) bool
 
+// V7Mode selects how a Generator fills the 74 bits which follow the timestamp
+// of a V7 UUID. The default V7Random does not order UUIDs created within the
+// same millisecond. The other modes are the RFC9562 section 6.2 methods which
+// ensure that every V7 UUID from a Generator sorts after the previous one.
+type V7Mode uint8
+
+const (
+	// V7Random fills every bit with random data.
+	V7Random V7Mode = iota
+
+	// V7Counter uses a 42 bit counter which is seeded randomly each
+	// millisecond and incremented for each UUID within it. The remaining 32
+	// bits are random.
+	V7Counter
+
+	// V7MonotonicRandom increments the previous 74 bits by a random amount
+	// for each UUID within the same millisecond.
+	V7MonotonicRandom
+)
+
+const (
+	v7CounterBits = 42
+	v7RandomBits  = 62
+	v7RandABits   = 12
+)
+
+// v7State tracks the last V7 UUID created by a Generator in one of the
+// monotonic V7Modes.
+type v7State struct {
+	// milli is the last timestamp used which may run ahead of the clock when
+	// the counter overflows
+	milli uint64
+
+	// counter holds the V7Counter or the rand_a bits for V7MonotonicRandom
+	counter uint64
+
+	// random holds the rand_b bits for V7MonotonicRandom
+	random uint64
+}
+
 // Generator is used to create and monitor the running of V1 and V2, and V4
 // UUIDs. It can be setup to take different implementations for Timestamp, Node
 // and CPRNG retrieval. This is also where the Saver implementation can be
@@ -56,11 +115,19 @@ type Generator struct {
 
 	// Once ensures that the generator is only setup and initialised once.
 	// This will occur either when you explicitly call the
-	// uuid.Generator.Init function or when a V1 or V2 id is generated.
+	// uuid.Generator.Init function or when the first V1, V2 or V6 id is
+	// generated. Each Generator has its own Once.
 	sync.Once
 
+	// err holds the last error for Error and is guarded by the lock
 	err error
 
+	// initErr holds any error from init. It is only written within the Once.
+	initErr error
+
+	// initialised is set when init has read the Store
+	initialised bool
+
 	// Store contains the current values being used by the Generator.
 	*Store
 
@@ -76,6 +143,18 @@ type Generator struct {
 	// Random as per the type Random func([]byte) (int, error)
 	Random
 
+	// V7Mode as per the type V7Mode
+	V7Mode V7Mode
+
+	// Logger receives structured records of initialisation and CPRNG
+	// failures. It is never given the node id.
+	Logger *slog.Logger
+
+	v7 v7State
+
+	// ulid is the last monotonic ULID
+	ulid array
+
 	// Intended to provide a non-volatile store to save the state of the
 	// generator, the default is nil and to therefore generate a timestamp
 	// clock sequence with random data. You can register your own save by
@@ -89,7 +168,7 @@ type Generator struct {
 // uuid.NewGenerator or RegisterGenerator. You can supply your own
 // implementations for CPRNG, Node Id and Timestamp retrieval. You can also
 // adjust the resolution of the default Timestamp spinner and supply your own
-// error handler CPRNG failures.
+// error handler CPRNG failures. The Logger is silent by default.
 type GeneratorConfig struct {
 	Saver
 	Next
@@ -97,13 +176,14 @@ type GeneratorConfig struct {
 	Id
 	Random
 	HandleError
+	V7Mode
+	Logger *slog.Logger
 }
 
-// NewGenerator will create a new uuid.Generator with the given functions.
+// NewGenerator will create a new uuid.Generator with the given functions. It
+// is initialised lazily by the first V1, V2 or V6 UUID, or by calling Init.
 func NewGenerator(pConfig GeneratorConfig) (gen *Generator) {
-	gen = newGenerator(pConfig)
-	generator.Do(generator.init)
-	return
+	return newGenerator(pConfig)
 }
 
 func newGenerator(pConfig GeneratorConfig) (gen *Generator) {
@@ -135,45 +215,102 @@ func newGenerator(pConfig GeneratorConfig) (gen *Generator) {
 	} else {
 		gen.HandleError = pConfig.HandleError
 	}
+	if pConfig.Logger == nil {
+		gen.Logger = discardLogger
+	} else {
+		gen.Logger = pConfig.Logger
+	}
 	gen.Saver = pConfig.Saver
+	gen.V7Mode = pConfig.V7Mode
 	gen.Store = new(Store)
 	return
 }
 
-// Init will initialise the default generator with default settings
+// Init will initialise the default generator with default settings. It
+// returns ErrGeneratorRegistered if a uuid.Register* method has already been
+// called.
 func Init() error {
 	return RegisterGenerator(GeneratorConfig{})
 }
 
-// RegisterGenerator will set the default generator to the given generator
-// Like uuid.Init this can only be called once. Any subsequent calls will have no
-// effect. If you call this you do not need to call uuid.Init
+// RegisterGenerator will set the default generator to a new generator with the
+// given config and initialise it. Like uuid.Init this can only be called once,
+// any subsequent calls return ErrGeneratorRegistered and have no effect. If you
+// call this you do not need to call uuid.Init
 func RegisterGenerator(pConfig GeneratorConfig) (err error) {
+	if !registered.CompareAndSwap(false, true) {
+		return ErrGeneratorRegistered
+	}
 	gen := newGenerator(pConfig)
+	generator.Store(gen)
+	return gen.Init()
+}
 
-	notOnce := true
-	once.Do(func() {
-		generator = gen
-		generator.Do(generator.init)
-		err = generator.Error()
-		notOnce = false
-		return
-	})
-	if notOnce {
-		log.Panicf("A uuid.Register* method cannot be called more than once.")
+// ResetDefault closes the default generator and replaces it with a new one
+// using the default settings. RegisterGenerator and Init can then be called
+// again. It is intended for tests and any error is from closing the previous
+// generator.
+func ResetDefault() error {
+	old := generator.Swap(newGenerator(GeneratorConfig{}))
+	registered.Store(false)
+	return old.Close()
+}
+
+// Init initialises the Generator, reading its state from the Saver if it has
+// one. It is run automatically by the first V1, V2 or V6 UUID and only has an
+// effect on the first call. Any error from initialisation is returned by every
+// call.
+func (o *Generator) Init() error {
+	o.Do(o.init)
+	return o.initErr
+}
+
+// Close saves the current state of an initialised Generator to its Saver. If
+// the Saver implements io.Closer it is then closed so that it can flush any
+// state held back by its save schedule. The Generator should not be used
+// after Close.
+func (o *Generator) Close() error {
+	o.Lock()
+	defer o.Unlock()
+
+	if o.Saver == nil {
+		return nil
 	}
-	return
+	if o.initialised {
+		o.Save(*o.Store)
+	}
+	if closer, ok := o.Saver.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
 }
 
 // Error will return any error from the uuid.Generator if a UUID returns as Nil
-// or nil
+// or nil and clears it. The error is shared by all callers so under concurrent
+// use it may belong to another call. Use the E methods, such as NewV4E, to
+// receive the error of each call.
 func (o *Generator) Error() (err error) {
+	o.Lock()
+	defer o.Unlock()
 	err = o.err
 	o.err = nil
 	return
 }
 
-func (o *Generator) read() {
+// setError records the error for Error
+func (o *Generator) setError(pErr error) {
+	o.Lock()
+	defer o.Unlock()
+	o.err = pErr
+}
+
+// read advances the Timestamp and Sequence and returns a copy of the state
+// taken while the lock is held. UUIDs must be built from the copy as the
+// Generator may be advanced by another call as soon as the lock is released.
+func (o *Generator) read() (state Store) {
+
+	// Initialise on first use
+	o.Do(o.init)
 
 	// Save the state (current timestamp, clock sequence, and node ID)
 	// back to the stable store
@@ -197,6 +334,7 @@ func (o *Generator) read() {
 
 	// Update the timestamp
 	o.Timestamp = now
+	return *o.Store
 }
 
 func (o *Generator) init() {
@@ -214,6 +352,8 @@ func (o *Generator) init() {
 	if o.Saver != nil {
 		err, storage = o.Read()
 		if err != nil {
+			o.Logger.Warn("uuid.Generator.init: could not read the saved state, it will not be saved",
+				slog.Any("error", err))
 			o.Saver = nil
 		}
 	}
@@ -226,13 +366,14 @@ func (o *Generator) init() {
 	node := o.Id()
 
 	if node == nil {
-		log.Println("uuid.Generator.init: address error: will generate random node id instead")
+		o.Logger.Info("uuid.Generator.init: no hardware address, generating a random node id")
 
 		node = make([]byte, 6)
 		n, err := o.Random(node)
 		if err != nil {
-			log.Printf("uuid.Generator.init: could not read random bytes into node - read [%d] %s", n, err)
-			o.err = err
+			o.Logger.Error("uuid.Generator.init: could not read random bytes",
+				slog.String("field", "node"), slog.Int("read", n), slog.Any("error", err))
+			o.err, o.initErr = err, err
 			return
 		}
 		// Mark as randomly generated
@@ -267,11 +408,12 @@ func (o *Generator) init() {
 		n, err := o.Random(b)
 		if err == nil {
 			storage.Sequence = Sequence(binary.BigEndian.Uint16(b))
-			log.Printf("uuid.Generator.init initialised random sequence: [%d]", storage.Sequence)
-
+			o.Logger.Debug("uuid.Generator.init: initialised random sequence",
+				slog.Any("sequence", storage.Sequence))
 		} else {
-			log.Printf("uuid.Generator.init: could not read random bytes into sequence - read [%d] %s", n, err)
-			o.err = err
+			o.Logger.Error("uuid.Generator.init: could not read random bytes",
+				slog.String("field", "sequence"), slog.Int("read", n), slog.Any("error", err))
+			o.err, o.initErr = err, err
 			return
 		}
 	} else if now < storage.Timestamp {
@@ -284,6 +426,7 @@ func (o *Generator) init() {
 	storage.Node = node
 
 	o.Store = &storage
+	o.initialised = true
 }
 
 func (o *Generator) save() {
@@ -299,26 +442,63 @@ func (o *Generator) save() {
 // NewV1 generates a new RFC4122 version 1 UUID based on a 60 bit timestamp and
 // node id
 func (o *Generator) NewV1() Uuid {
-	o.read()
-	id := array{}
+	id := o.newV1()
+	return id[:]
+}
+
+// NewV1ID is the same as NewV1 but returns an ID without allocating.
+func (o *Generator) NewV1ID() ID {
+	return ID(o.newV1())
+}
+
+// NewV1E is the same as NewV1 but returns nil and the error if the Generator
+// could not be initialised.
+func (o *Generator) NewV1E() (Uuid, error) {
+	if err := o.Init(); err != nil {
+		return nil, err
+	}
+	id := o.newV1()
+	return id[:], nil
+}
+
+func (o *Generator) newV1() (id array) {
+	state := o.read()
 
 	makeUuid(&id,
-		uint32(o.Timestamp),
-		uint16(o.Timestamp>>32),
-		uint16(o.Timestamp>>48),
-		uint16(o.Sequence),
-		o.Node)
+		uint32(state.Timestamp),
+		uint16(state.Timestamp>>32),
+		uint16(state.Timestamp>>48),
+		uint16(state.Sequence),
+		state.Node)
 
 	id.setRFC4122Version(1)
-	return id[:]
+	return
 }
 
 // NewV2 generates a new DCE version 2 UUID based on a 60 bit timestamp, node id
 // and POSIX UID or GID
 func (o *Generator) NewV2(pDomain Domain) Uuid {
-	o.read()
+	id := o.newV2(pDomain)
+	return id[:]
+}
 
-	id := array{}
+// NewV2ID is the same as NewV2 but returns an ID without allocating.
+func (o *Generator) NewV2ID(pDomain Domain) ID {
+	return ID(o.newV2(pDomain))
+}
+
+// NewV2E is the same as NewV2 but returns nil and the error if the Generator
+// could not be initialised.
+func (o *Generator) NewV2E(pDomain Domain) (Uuid, error) {
+	if err := o.Init(); err != nil {
+		return nil, err
+	}
+	id := o.newV2(pDomain)
+	return id[:], nil
+}
+
+func (o *Generator) newV2(pDomain Domain) (id array) {
+	state := o.read()
 
 	var domain uint32
 
@@ -331,16 +511,297 @@ func (o *Generator) NewV2(pDomain Domain) Uuid {
 
 	makeUuid(&id,
 		domain,
-		uint16(o.Timestamp>>32),
-		uint16(o.Timestamp>>48),
-		uint16(o.Sequence),
-		o.Node)
+		uint16(state.Timestamp>>32),
+		uint16(state.Timestamp>>48),
+		uint16(state.Sequence),
+		state.Node)
 
 	id[9] = byte(pDomain)
 	id.setRFC4122Version(2)
+	return
+}
+
+// NewV4 generates a new RFC4122 version 4 UUID from the Generator's Random
+// function. If Random fails the HandleError policy is applied and nil is
+// returned if it fails again.
+func (o *Generator) NewV4() Uuid {
+	id, err := o.retry("uuid.V4", o.HandleError, o.newV4)
+	if err != nil {
+		return nil
+	}
 	return id[:]
 }
 
+// NewV4ID is the same as NewV4 but returns an ID without allocating. If Random
+// fails the default HandleError panics, otherwise the Nil ID is returned. Use
+// NewV4IDE to receive the error.
+func (o *Generator) NewV4ID() ID {
+	id, _ := o.retry("uuid.V4", o.HandleError, o.newV4)
+	return ID(id)
+}
+
+// NewV4IDE is the same as NewV4E but returns an ID without allocating. The Nil
+// ID is returned with any error.
+func (o *Generator) NewV4IDE() (ID, error) {
+	id, err := o.retry("uuid.V4", o.handleErrorE(), o.newV4)
+	return ID(id), err
+}
+
+// NewV4E is the same as NewV4 but returns the error from Random along with
+// nil. A HandleError given to the Generator is still applied and the error is
+// that of the last attempt. The default HandleError, which panics, is not
+// used.
+func (o *Generator) NewV4E() (Uuid, error) {
+	id, err := o.retry("uuid.V4", o.handleErrorE(), o.newV4)
+	if err != nil {
+		return nil, err
+	}
+	return id[:], nil
+}
+
+func (o *Generator) newV4() (id array, err error) {
+	_, err = o.readRandom(id[:])
+	id.setRFC4122Version(4)
+	return
+}
+
+// NewV6 generates a new RFC9562 version 6 UUID based on a 60 bit timestamp and
+// node id. It shares the Timestamp, Sequence and Node of V1 UUIDs but stores
+// the timestamp most significant bits first so that it sorts by time.
+func (o *Generator) NewV6() Uuid {
+	id := o.newV6()
+	return id[:]
+}
+
+// NewV6ID is the same as NewV6 but returns an ID without allocating.
+func (o *Generator) NewV6ID() ID {
+	return ID(o.newV6())
+}
+
+// NewV6E is the same as NewV6 but returns nil and the error if the Generator
+// could not be initialised.
+func (o *Generator) NewV6E() (Uuid, error) {
+	if err := o.Init(); err != nil {
+		return nil, err
+	}
+	id := o.newV6()
+	return id[:], nil
+}
+
+func (o *Generator) newV6() (id array) {
+	state := o.read()
+
+	makeUuid(&id,
+		uint32(state.Timestamp>>28),
+		uint16(state.Timestamp>>12),
+		uint16(state.Timestamp&0x0fff),
+		uint16(state.Sequence),
+		state.Node)
+
+	id.setRFC4122Version(6)
+	return
+}
+
+// NewV7 generates a new RFC9562 version 7 UUID based on a 48 bit Unix epoch
+// millisecond timestamp taken from the Generator's Next function and 74 bits
+// of random data taken from its Random function. V7 UUIDs sort by their time
+// of creation.
+func (o *Generator) NewV7() Uuid {
+	id, err := o.retry("uuid.V7", o.HandleError, o.newV7)
+	if err != nil {
+		return nil
+	}
+	return id[:]
+}
+
+// NewV7ID is the same as NewV7 but returns an ID without allocating. If Random
+// fails the default HandleError panics, otherwise the Nil ID is returned. Use
+// NewV7IDE to receive the error.
+func (o *Generator) NewV7ID() ID {
+	id, _ := o.retry("uuid.V7", o.HandleError, o.newV7)
+	return ID(id)
+}
+
+// NewV7IDE is the same as NewV7E but returns an ID without allocating. The Nil
+// ID is returned with any error.
+func (o *Generator) NewV7IDE() (ID, error) {
+	id, err := o.retry("uuid.V7", o.handleErrorE(), o.newV7)
+	return ID(id), err
+}
+
+// NewV7E is the same as NewV7 but returns the error from Random along with
+// nil. HandleError is applied as for NewV4E.
+func (o *Generator) NewV7E() (Uuid, error) {
+	id, err := o.retry("uuid.V7", o.handleErrorE(), o.newV7)
+	if err != nil {
+		return nil, err
+	}
+	return id[:], nil
+}
+
+func (o *Generator) newV7() (id array, err error) {
+	if _, err = o.readRandom(id[versionIndex:]); err != nil {
+		return
+	}
+
+	o.Lock()
+	defer o.Unlock()
+
+	now := o.Next().UnixMilli()
+
+	switch o.V7Mode {
+	case V7Counter:
+		o.nextV7Counter(&id, now)
+		now = o.v7.milli
+	case V7MonotonicRandom:
+		o.nextV7MonotonicRandom(&id, now)
+		now = o.v7.milli
+	}
+
+	setUnixMilli(&id, now)
+	id.setRFC4122Version(7)
+	return
+}
+
+// retry runs a UUID function which relies on random data. If it fails the
+// error is recorded and the given HandleError policy decides whether it is run
+// again. An empty array is returned with any error.
+func (o *Generator) retry(pName string, fHandle HandleError, fNew func() (array, error)) (array, error) {
+	id, err := fNew()
+	if err != nil && o.handle(pName, err, fHandle) {
+		id, err = fNew()
+		if err != nil {
+			o.handle(pName, err, nil)
+		}
+	}
+	if err != nil {
+		return array{}, err
+	}
+	return id, nil
+}
+
+// handle records and logs an error from Random. After a first attempt it
+// returns whether the HandleError policy wants another. A nil policy marks
+// the failure of a retry.
+func (o *Generator) handle(pName string, pErr error, fHandle HandleError) bool {
+	o.setError(pErr)
+	if fHandle == nil {
+		o.Logger.Error("uuid.Generator: could not read random bytes after retry",
+			slog.String("uuid", pName), slog.Any("error", pErr))
+		return false
+	}
+	o.Logger.Error("uuid.Generator: could not read random bytes",
+		slog.String("uuid", pName), slog.Any("error", pErr))
+	return fHandle(pErr)
+}
+
+// defaultHandleError is the code pointer of the default HandleError
+var defaultHandleError = reflect.ValueOf(runHandleError).Pointer()
+
+// handleErrorE returns the HandleError policy for the methods which return an
+// error. The default policy panics so it is replaced by one which returns the
+// error without a retry. The check is made on each call as HandleError may be
+// replaced after NewGenerator.
+func (o *Generator) handleErrorE() HandleError {
+	if reflect.ValueOf(o.HandleError).Pointer() == defaultHandleError {
+		return noRetry
+	}
+	return o.HandleError
+}
+
+func noRetry(error) bool {
+	return false
+}
+
+// cryptoRead is the code pointer of crypto/rand.Read, the default Random
+var cryptoRead = reflect.ValueOf(rand.Read).Pointer()
+
+// readRandom reads from the Generator's Random function without the lock.
+// When Random is crypto/rand.Read it is called directly so that pDst does not
+// escape to the heap. Any other Random is given its own buffer. The check is
+// made on each call as Random may be replaced after NewGenerator.
+func (o *Generator) readRandom(pDst []byte) (n int, err error) {
+	if reflect.ValueOf(o.Random).Pointer() == cryptoRead {
+		return rand.Read(pDst)
+	}
+	buf := make([]byte, len(pDst))
+	n, err = o.Random(buf)
+	copy(pDst, buf)
+	return
+}
+
+// Updates the V7Counter state for the given time and writes the counter into
+// the rand_a and leading rand_b bits of the id. The id must already hold
+// random data. If the counter overflows, the overflow is carried into the
+// timestamp so that no value is ever reissued. This mirrors the spinner which
+// never reissues a Timestamp once its Resolution runs out.
+func (o *Generator) nextV7Counter(pId *array, pMilli uint64) {
+	if pMilli > o.v7.milli {
+		o.v7.milli = pMilli
+		o.v7.counter = seedV7Counter(pId)
+	} else {
+		o.v7.counter++
+		if o.v7.counter == 1<<v7CounterBits {
+			o.v7.milli++
+			o.v7.counter = seedV7Counter(pId)
+		}
+	}
+	pId.setBits(versionBit+versionBits, v7RandABits, o.v7.counter>>(v7CounterBits-v7RandABits))
+	pId.setBits(variantBit+variantBits, v7CounterBits-v7RandABits, o.v7.counter)
+}
+
+// Seeds the counter from the random bits of the id leaving the most
+// significant bit clear to guard against early rollover.
+func seedV7Counter(pId *array) uint64 {
+	high := pId.bits(versionBit+versionBits, v7RandABits)
+	low := pId.bits(variantBit+variantBits, v7CounterBits-v7RandABits)
+	return (high<<(v7CounterBits-v7RandABits) | low) & (1<<(v7CounterBits-1) - 1)
+}
+
+// Updates the V7MonotonicRandom state for the given time and writes it into
+// the rand_a and rand_b bits of the id. The id must already hold random data.
+// Within the same millisecond the previous value is incremented by a random
+// amount and any overflow is carried into the timestamp.
+func (o *Generator) nextV7MonotonicRandom(pId *array, pMilli uint64) {
+	randA := pId.bits(versionBit+versionBits, v7RandABits)
+	randB := pId.bits(variantBit+variantBits, v7RandomBits)
+
+	if pMilli > o.v7.milli {
+		o.v7.milli = pMilli
+		o.v7.counter, o.v7.random = randA, randB
+	} else {
+		o.v7.random += uint64(binary.BigEndian.Uint32(pId[length-4:])) + 1
+		if o.v7.random >= 1<<v7RandomBits {
+			o.v7.random -= 1 << v7RandomBits
+			o.v7.counter++
+			if o.v7.counter == 1<<v7RandABits {
+				o.v7.milli++
+				o.v7.counter, o.v7.random = randA, randB
+			}
+		}
+	}
+	pId.setBits(versionBit+versionBits, v7RandABits, o.v7.counter)
+	pId.setBits(variantBit+variantBits, v7RandomBits, o.v7.random)
+}
+
+// Writes the 48 bit big endian millisecond timestamp into the first six
+// octets of a V7 UUID
+func setUnixMilli(pId *array, pMilli uint64) {
+	pId[0] = byte(pMilli >> 40)
+	pId[1] = byte(pMilli >> 32)
+	pId[2] = byte(pMilli >> 24)
+	pId[3] = byte(pMilli >> 16)
+	pId[4] = byte(pMilli >> 8)
+	pId[5] = byte(pMilli)
+}
+
+// Reads the 48 bit big endian millisecond timestamp from the first six octets
+// of a V7 UUID
+func unixMilli(pId []byte) uint64 {
+	return uint64(pId[0])<<40 | uint64(pId[1])<<32 | uint64(pId[2])<<24 |
+		uint64(pId[3])<<16 | uint64(pId[4])<<8 | uint64(pId[5])
+}
+
 func makeUuid(pId *array, pLow uint32, pMid, pHiAndV, seq uint16, pNode Node) {
 
 	pId[0] = byte(pLow >> 24)
@@ -360,6 +821,22 @@ func makeUuid(pId *array, pLow uint32, pMid, pHiAndV, seq uint16, pNode Node) {
 	copy(pId[10:], pNode)
 }
 
+// Reads the 60 bit timestamp from the time_low, time_mid and time_hi fields
+// of a V1 UUID
+func timestampV1(pId []byte) Timestamp {
+	return Timestamp(binary.BigEndian.Uint16(pId[6:8])&0x0fff)<<48 |
+		Timestamp(binary.BigEndian.Uint16(pId[4:6]))<<32 |
+		Timestamp(binary.BigEndian.Uint32(pId[0:4]))
+}
+
+// Reads the 60 bit timestamp from the time_high, time_mid and time_low fields
+// of a V6 UUID
+func timestampV6(pId []byte) Timestamp {
+	return Timestamp(binary.BigEndian.Uint32(pId[0:4]))<<28 |
+		Timestamp(binary.BigEndian.Uint16(pId[4:6]))<<12 |
+		Timestamp(binary.BigEndian.Uint16(pId[6:8])&0x0fff)
+}
+
 func findFirstHardwareAddress() (node Node) {
 	interfaces, err := net.Interfaces()
 	if err == nil {
@@ -367,7 +844,6 @@ func findFirstHardwareAddress() (node Node) {
 			if i.Flags&net.FlagUp != 0 && bytes.Compare(i.HardwareAddr, nil) != 0 {
 				// Don't use random as we have a real address
 				node = Node(i.HardwareAddr)
-				log.Println("uuid.findFirstHardwareAddress:", node)
 				break
 			}
 		}
@@ -376,6 +852,5 @@ func findFirstHardwareAddress() (node Node) {
 }
 
 func runHandleError(pErr error) bool {
-	log.Panicln("uuid.Generator ran into a serious problem with the random generator", pErr)
-	return false
+	panic("uuid.Generator ran into a serious problem with the random generator: " + pErr.Error())
 }
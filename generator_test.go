@@ -0,0 +1,434 @@
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGenerator_NewV6(t *testing.T) {
+	now := Now()
+	gen := newGenerator(GeneratorConfig{
+		Next: func() Timestamp {
+			now = now.Add(time.Microsecond)
+			return now
+		},
+	})
+
+	last := gen.NewV6()
+	assert.Equal(t, Six, last.Version())
+	assert.Equal(t, VariantRFC4122, last.Variant())
+	assert.Equal(t, gen.Timestamp, timestampV6(last))
+
+	for i := 0; i < 100; i++ {
+		id := gen.NewV6()
+		assert.Equal(t, -1, Compare(last, id), "V6 UUIDs should sort by time")
+		last = id
+	}
+}
+
+func TestNewV7(t *testing.T) {
+	before := uint64(time.Now().UnixNano() / 1e6)
+	id := NewV7()
+	after := uint64(time.Now().UnixNano() / 1e6)
+
+	assert.Equal(t, Seven, id.Version())
+	assert.Equal(t, VariantRFC4122, id.Variant())
+
+	ms := unixMilli(id)
+	assert.True(t, ms >= before && ms <= after, "Timestamp should be the current time")
+}
+
+func TestGenerator_NewV7(t *testing.T) {
+	now := Now()
+	gen := newGenerator(GeneratorConfig{
+		Next: func() Timestamp {
+			now = now.Add(time.Millisecond)
+			return now
+		},
+	})
+
+	last := gen.NewV7()
+	for i := 0; i < 100; i++ {
+		id := gen.NewV7()
+		assert.Equal(t, -1, Compare(last, id), "V7 UUIDs should sort by time")
+		last = id
+	}
+}
+
+func TestGenerator_NewV7_Error(t *testing.T) {
+	handled := 0
+	gen := newGenerator(GeneratorConfig{
+		Random: func([]byte) (int, error) {
+			return 0, errors.New("no entropy")
+		},
+		HandleError: func(error) bool {
+			handled++
+			return true
+		},
+	})
+
+	assert.Nil(t, gen.NewV7(), "Should return nil on random failure")
+	assert.Equal(t, 1, handled, "HandleError should be called once")
+	assert.Error(t, gen.Error())
+	assert.NoError(t, gen.Error(), "Error should be cleared once read")
+}
+
+func TestGenerator_Logger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	node := Node{0x02, 0xfc, 0xde, 0xad, 0xbe, 0xef}
+	gen := newGenerator(GeneratorConfig{
+		Id: func() Node {
+			return node
+		},
+		Logger: logger,
+	})
+	gen.NewV1()
+	assert.Contains(t, buf.String(), `"msg":"uuid.Generator.init: initialised random sequence"`)
+	assert.NotContains(t, buf.String(), "deadbeef", "The node id should never be logged")
+
+	buf.Reset()
+	gen = newGenerator(GeneratorConfig{
+		Random: func([]byte) (int, error) {
+			return 0, errors.New("no entropy")
+		},
+		HandleError: func(error) bool {
+			return false
+		},
+		Logger: logger,
+	})
+	gen.NewV4()
+	assert.Contains(t, buf.String(), `"level":"ERROR"`)
+	assert.Contains(t, buf.String(), `"uuid":"uuid.V4"`)
+	assert.Contains(t, buf.String(), `"error":"no entropy"`)
+
+	assert.Equal(t, discardLogger, newGenerator(GeneratorConfig{}).Logger, "The default should be silent")
+}
+
+func TestGenerator_HandleError_Default(t *testing.T) {
+	gen := newGenerator(GeneratorConfig{
+		Random: func([]byte) (int, error) {
+			return 0, errors.New("no entropy")
+		},
+	})
+	assert.PanicsWithValue(t, "uuid.Generator ran into a serious problem with the random generator: no entropy", func() {
+		gen.NewV4()
+	})
+}
+
+func TestGenerator_NewE(t *testing.T) {
+	gen := newGenerator(GeneratorConfig{})
+	for _, fNew := range []func() (Uuid, error){
+		gen.NewV1E,
+		func() (Uuid, error) { return gen.NewV2E(DomainUser) },
+		gen.NewV4E,
+		gen.NewV6E,
+		gen.NewV7E,
+		gen.NewULIDE,
+		gen.NewMonotonicULIDE,
+	} {
+		id, err := fNew()
+		assert.NoError(t, err)
+		assert.Len(t, id, length)
+	}
+
+	handled := 0
+	failure := errors.New("no entropy")
+	gen = newGenerator(GeneratorConfig{
+		Id: func() Node {
+			return nil
+		},
+		Random: func([]byte) (int, error) {
+			return 0, failure
+		},
+		HandleError: func(error) bool {
+			handled++
+			return true
+		},
+	})
+	for _, fNew := range []func() (Uuid, error){
+		gen.NewV1E,
+		func() (Uuid, error) { return gen.NewV2E(DomainUser) },
+		gen.NewV6E,
+	} {
+		id, err := fNew()
+		assert.Equal(t, failure, err, "Should return the init error on every call")
+		assert.Nil(t, id)
+	}
+	assert.Zero(t, handled)
+
+	for _, fNew := range []func() (Uuid, error){gen.NewV4E, gen.NewV7E, gen.NewULIDE, gen.NewMonotonicULIDE} {
+		id, err := fNew()
+		assert.Equal(t, failure, err)
+		assert.Nil(t, id)
+	}
+	assert.Equal(t, 4, handled, "HandleError should be called once per call")
+
+	gen = newGenerator(GeneratorConfig{
+		Random: func([]byte) (int, error) {
+			return 0, failure
+		},
+	})
+	for _, fNew := range []func() (Uuid, error){gen.NewV4E, gen.NewV7E, gen.NewULIDE, gen.NewMonotonicULIDE} {
+		assert.NotPanics(t, func() {
+			id, err := fNew()
+			assert.Equal(t, failure, err, "The default HandleError should not panic")
+			assert.Nil(t, id)
+		})
+	}
+}
+
+func TestGenerator_Random_Replaced(t *testing.T) {
+	failure := errors.New("no entropy")
+	gen := NewGenerator(GeneratorConfig{
+		HandleError: func(error) bool {
+			return false
+		},
+	})
+	id, err := gen.NewV4E()
+	assert.NoError(t, err)
+	assert.NotNil(t, id)
+
+	gen.Random = func([]byte) (int, error) {
+		return 0, failure
+	}
+	for name, fNew := range map[string]func() error{
+		"V4":            func() error { _, err := gen.NewV4E(); return err },
+		"V4ID":          func() error { _, err := gen.NewV4IDE(); return err },
+		"V7":            func() error { _, err := gen.NewV7E(); return err },
+		"V7ID":          func() error { _, err := gen.NewV7IDE(); return err },
+		"ULID":          func() error { _, err := gen.NewULIDE(); return err },
+		"MonotonicULID": func() error { _, err := gen.NewMonotonicULIDE(); return err },
+		"FillV4":        func() error { return gen.FillV4(make([]ID, 2)) },
+		"FillV7":        func() error { return gen.FillV7(make([]ID, 2)) },
+	} {
+		assert.Equal(t, failure, fNew(), "%s should use the replaced Random", name)
+	}
+	assert.Nil(t, gen.NewV4())
+	assert.Equal(t, ID{}, gen.NewV7ID())
+}
+
+func TestGenerator_HandleError_Replaced(t *testing.T) {
+	failure := errors.New("no entropy")
+	gen := NewGenerator(GeneratorConfig{
+		Random: func([]byte) (int, error) {
+			return 0, failure
+		},
+	})
+
+	handled := 0
+	gen.HandleError = func(error) bool {
+		handled++
+		return true
+	}
+	_, err := gen.NewV4E()
+	assert.Equal(t, failure, err)
+	_, err = gen.NewV7IDE()
+	assert.Equal(t, failure, err)
+	assert.Equal(t, failure, gen.FillV4(make([]ID, 2)))
+	assert.Equal(t, 3, handled, "The replaced HandleError should be applied")
+
+	gen.HandleError = runHandleError
+	assert.NotPanics(t, func() {
+		_, err = gen.NewV4E()
+	}, "The default HandleError should not be used")
+	assert.Equal(t, 3, handled)
+}
+
+func TestGenerator_NewV4E_Concurrent(t *testing.T) {
+	var calls int64
+	var mu sync.Mutex
+	gen := newGenerator(GeneratorConfig{
+		Random: func(pDst []byte) (int, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			if calls%2 == 0 {
+				return 0, errors.New("no entropy")
+			}
+			return len(pDst), nil
+		},
+		HandleError: func(error) bool {
+			return false
+		},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := gen.NewV4E()
+			assert.True(t, (id == nil) == (err != nil), "Each call should receive its own error")
+			gen.Error()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestGenerator_NewV7_Monotonic(t *testing.T) {
+	now := Now()
+	for _, mode := range []V7Mode{V7Counter, V7MonotonicRandom} {
+		gen := newGenerator(GeneratorConfig{
+			V7Mode: mode,
+			Next: func() Timestamp {
+				return now
+			},
+		})
+
+		last := gen.NewV7()
+		for i := 0; i < 10000; i++ {
+			id := gen.NewV7()
+			assert.Equal(t, Seven, id.Version())
+			assert.Equal(t, VariantRFC4122, id.Variant())
+			if !assert.Equal(t, -1, bytes.Compare(last, id), "Mode %d should sort within a millisecond", mode) {
+				return
+			}
+			last = id
+		}
+	}
+}
+
+func TestGenerator_NewV7_Overflow(t *testing.T) {
+	now := Now()
+	gen := newGenerator(GeneratorConfig{
+		V7Mode: V7Counter,
+		Next: func() Timestamp {
+			return now
+		},
+	})
+
+	first := gen.NewV7()
+	gen.v7.counter = 1<<v7CounterBits - 1
+	id := gen.NewV7()
+
+	assert.Equal(t, now.UnixMilli()+1, gen.v7.milli, "Overflow should carry into the timestamp")
+	assert.Equal(t, -1, bytes.Compare(first, id))
+
+	gen = newGenerator(GeneratorConfig{
+		V7Mode: V7MonotonicRandom,
+		Next: func() Timestamp {
+			return now
+		},
+	})
+
+	first = gen.NewV7()
+	gen.v7.counter, gen.v7.random = 1<<v7RandABits-1, 1<<v7RandomBits-1
+	id = gen.NewV7()
+
+	assert.Equal(t, now.UnixMilli()+1, gen.v7.milli, "Overflow should carry into the timestamp")
+	assert.Equal(t, -1, bytes.Compare(first, id))
+}
+
+func TestGenerator_NewV7_Concurrent(t *testing.T) {
+	gen := newGenerator(GeneratorConfig{V7Mode: V7Counter})
+
+	var wg sync.WaitGroup
+	ids := make([][]Uuid, 8)
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				ids[i] = append(ids[i], gen.NewV7())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	for _, v := range ids {
+		for j, id := range v {
+			assert.False(t, seen[string(id)], "UUIDs should be unique")
+			seen[string(id)] = true
+			if j > 0 {
+				assert.Equal(t, -1, bytes.Compare(v[j-1], id), "UUIDs should sort within a goroutine")
+			}
+		}
+	}
+}
+
+func TestVersion_Seven(t *testing.T) {
+	assert.Equal(t, Seven, resolveVersion(7))
+	assert.Contains(t, Seven.String(), "Version 7")
+}
+
+func TestRegisterGenerator(t *testing.T) {
+	assert.NoError(t, ResetDefault())
+	defer ResetDefault()
+
+	node := Node{0x02, 0x01, 0x02, 0x03, 0x04, 0x05}
+	assert.NoError(t, RegisterGenerator(GeneratorConfig{
+		Id: func() Node {
+			return node
+		},
+	}))
+	assert.Equal(t, Uuid(node), NewV1()[10:])
+
+	assert.NotPanics(t, func() {
+		assert.Equal(t, ErrGeneratorRegistered, RegisterGenerator(GeneratorConfig{}))
+		assert.Equal(t, ErrGeneratorRegistered, Init())
+	})
+	assert.Equal(t, Uuid(node), NewV1()[10:], "A second call should have no effect")
+
+	assert.NoError(t, ResetDefault())
+	assert.NoError(t, Init(), "Should be able to register after a reset")
+}
+
+func TestNewGenerator_Lazy(t *testing.T) {
+	assert.NoError(t, ResetDefault())
+	defer ResetDefault()
+
+	nodes := []Node{{0x02, 0, 0, 0, 0, 1}, {0x02, 0, 0, 0, 0, 2}}
+	gens := make([]*Generator, len(nodes))
+	for i := range nodes {
+		node := nodes[i]
+		gens[i] = NewGenerator(GeneratorConfig{
+			Id: func() Node {
+				return node
+			},
+		})
+		assert.False(t, gens[i].initialised, "NewGenerator should not initialise")
+	}
+	assert.False(t, generator.Load().initialised, "NewGenerator should not initialise the default generator")
+
+	for i, gen := range gens {
+		assert.Equal(t, Uuid(nodes[i]), gen.NewV1()[10:], "Each generator should initialise itself")
+		assert.True(t, gen.initialised)
+	}
+	assert.False(t, generator.Load().initialised)
+}
+
+type closingSaver struct {
+	saved  []Store
+	closed int
+}
+
+func (o *closingSaver) Read() (error, Store) { return nil, Store{} }
+func (o *closingSaver) Save(pStore Store)    { o.saved = append(o.saved, pStore) }
+func (o *closingSaver) Close() error         { o.closed++; return nil }
+
+func TestGenerator_Close(t *testing.T) {
+	saver := new(closingSaver)
+	gen := NewGenerator(GeneratorConfig{Saver: saver})
+	assert.NoError(t, gen.Close())
+	assert.Empty(t, saver.saved, "An uninitialised generator has no state to save")
+	assert.Equal(t, 1, saver.closed)
+
+	saver = new(closingSaver)
+	gen = NewGenerator(GeneratorConfig{Saver: saver})
+	gen.NewV1()
+	assert.NoError(t, gen.Close())
+	if assert.Len(t, saver.saved, 2) {
+		assert.Equal(t, *gen.Store, saver.saved[1], "Close should save the current state")
+	}
+	assert.Equal(t, 1, saver.closed)
+
+	assert.NoError(t, NewGenerator(GeneratorConfig{}).Close())
+}
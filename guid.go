@@ -0,0 +1,60 @@
+package uuid
+
+import (
+	"database/sql/driver"
+)
+
+// Microsoft GUIDs, such as those from Windows APIs and SQL Server
+// uniqueidentifier columns, store the time_low, time_mid and
+// time_hi_and_version fields little endian. The text form is the same as a
+// UUID so only the byte order needs converting.
+
+// FromGUIDBytes creates a UUID from the 16 bytes of a Microsoft GUID. A slice
+// shorter than 16 bytes is padded with zeros the same as New.
+func FromGUIDBytes(pGuid []byte) Uuid {
+	o := array{}
+	o.unmarshal(pGuid)
+	o.swapGUID()
+	return o[:]
+}
+
+// GUIDBytes returns a copy of the Uuid in the byte order of a Microsoft GUID.
+func (o Uuid) GUIDBytes() []byte {
+	a := toArray(o)
+	a.swapGUID()
+	return a[:]
+}
+
+// GUIDBytes returns the ID in the byte order of a Microsoft GUID.
+func (o ID) GUIDBytes() []byte {
+	a := array(o)
+	a.swapGUID()
+	return a[:]
+}
+
+// Reverses the byte order of the first three fields. The swap is its own
+// inverse.
+func (o *array) swapGUID() {
+	o[0], o[1], o[2], o[3] = o[3], o[2], o[1], o[0]
+	o[4], o[5] = o[5], o[4]
+	o[6], o[7] = o[7], o[6]
+}
+
+// SQLGUID stores an ID as the 16 bytes of a Microsoft GUID, as used by SQL
+// Server uniqueidentifier columns, in the same way as SQLBinary.
+type SQLGUID ID
+
+// Value implements the driver.Valuer interface
+func (o SQLGUID) Value() (driver.Value, error) {
+	return ID(o).GUIDBytes(), nil
+}
+
+// Scan implements the sql.Scanner interface. A 16 byte slice is taken as a
+// GUID and text as any format accepted by Parse.
+func (o *SQLGUID) Scan(pSrc interface{}) error {
+	if src, ok := pSrc.([]byte); ok && len(src) == length {
+		*o = SQLGUID(FromGUIDBytes(src))
+		return nil
+	}
+	return (*ID)(o).Scan(pSrc)
+}
@@ -0,0 +1,59 @@
+package uuid
+
+import (
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var guidTests = []struct {
+	uuid string
+	guid []byte
+}{
+	{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", []byte{0x10, 0xb8, 0xa7, 0x6b, 0xad, 0x9d, 0xd1, 0x11, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}},
+
+	// From the documentation of System.Guid.ToByteArray
+	{"35918bc9-196d-40ea-9779-889d79b753f0", []byte{0xc9, 0x8b, 0x91, 0x35, 0x6d, 0x19, 0xea, 0x40, 0x97, 0x79, 0x88, 0x9d, 0x79, 0xb7, 0x53, 0xf0}},
+
+	// IID_IUnknown
+	{"00000000-0000-0000-c000-000000000046", []byte{0, 0, 0, 0, 0, 0, 0, 0, 0xc0, 0, 0, 0, 0, 0, 0, 0x46}},
+}
+
+func TestGUIDBytes(t *testing.T) {
+	for _, v := range guidTests {
+		id, err := Parse(v.uuid)
+		assert.NoError(t, err)
+		assert.Equal(t, v.guid, id.GUIDBytes(), "GUID bytes of %s", v.uuid)
+		assert.Equal(t, v.guid, ToID(id).GUIDBytes())
+		assert.Equal(t, id, FromGUIDBytes(v.guid))
+		assert.Equal(t, v.uuid, FromGUIDBytes(v.guid).String())
+	}
+
+	id := Uuid(NameSpaceDNS)
+	id.GUIDBytes()
+	assert.Equal(t, Uuid(NameSpaceDNS), id, "GUIDBytes should not change the Uuid")
+
+	assert.Equal(t, Uuid(Nil), FromGUIDBytes(nil))
+}
+
+func TestSQLGUID(t *testing.T) {
+	stub := &stubDriver{}
+	db := sql.OpenDB(stub)
+	defer db.Close()
+
+	for _, v := range guidTests {
+		id, _ := ParseID(v.uuid)
+		_, err := db.Exec("INSERT", SQLGUID(id))
+		assert.NoError(t, err)
+		assert.Equal(t, v.guid, stub.args[0])
+
+		stub.rows = stub.args
+		var out SQLGUID
+		assert.NoError(t, db.QueryRow("SELECT id").Scan(&out))
+		assert.Equal(t, id, ID(out))
+	}
+
+	var out SQLGUID
+	assert.NoError(t, out.Scan("6ba7b810-9dad-11d1-80b4-00c04fd430c8"), "Should scan text")
+	assert.Equal(t, ToID(NameSpaceDNS), ID(out))
+}
@@ -0,0 +1,127 @@
+package uuid
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+var _ UUID = ID{}
+
+// ID is a fixed size UUID value. Unlike Uuid it is comparable so it can be
+// used as a map key, copies do not share memory and it can never be nil or
+// short. The zero ID is the Nil UUID.
+type ID [length]byte
+
+// ToID copies any UUID into an ID. A nil UUID gives the Nil ID and a UUID
+// with fewer than 16 bytes is padded with zeros.
+func ToID(pId UUID) ID {
+	return ID(toArray(pId))
+}
+
+// ParseID is the same as Parse but returns an ID without allocating.
+func ParseID(pUuid string) (ID, error) {
+	id, err := parse(pUuid)
+	return ID(id), err
+}
+
+// Size returns the octet length of the ID
+func (o ID) Size() int {
+	return length
+}
+
+// Version returns the uuid.Version of the ID
+func (o ID) Version() Version {
+	return resolveVersion(o[versionIndex] >> 4)
+}
+
+// Variant returns the implementation variant of the ID
+func (o ID) Variant() uint8 {
+	return variant(o[variantIndex])
+}
+
+// Bytes returns a copy of the ID in network byte order
+func (o ID) Bytes() []byte {
+	return o[:]
+}
+
+// String returns the canonical string representation of the UUID or the
+// uuid.Format the package is set to via uuid.SwitchFormat
+func (o ID) String() string {
+	return currentFormat().sprint(o[:])
+}
+
+// Uuid returns a copy of the ID as a Uuid
+func (o ID) Uuid() Uuid {
+	return o[:]
+}
+
+// Immutable returns the ID as an Immutable
+func (o ID) Immutable() Immutable {
+	return Immutable(o[:])
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface
+func (o ID) MarshalBinary() ([]byte, error) {
+	return o[:], nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface
+func (o *ID) UnmarshalBinary(pBytes []byte) error {
+	if len(pBytes) != length {
+		return fmt.Errorf("uuid.ID.UnmarshalBinary: invalid length")
+	}
+	copy(o[:], pBytes)
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface in the same way
+// as Uuid.MarshalText
+func (o ID) MarshalText() ([]byte, error) {
+	return Uuid(o[:]).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface in the same
+// way as Uuid.UnmarshalText.
+func (o *ID) UnmarshalText(pUuid []byte) error {
+	id, err := parse(string(pUuid))
+	if err == nil {
+		*o = ID(id)
+	}
+	return err
+}
+
+// IsZero reports whether the ID is the Nil UUID. It lets the omitzero option
+// of encoding/json leave out unset IDs.
+func (o ID) IsZero() bool {
+	return o == ID{}
+}
+
+// Value implements the driver.Valuer interface
+func (o ID) Value() (driver.Value, error) {
+	return o.MarshalText()
+}
+
+// Scan implements the sql.Scanner interface
+func (o *ID) Scan(pSrc interface{}) error {
+	if pSrc == nil {
+		return nil
+	}
+	if pSrc == "" {
+		return nil
+	}
+	switch src := pSrc.(type) {
+
+	case string:
+		return o.UnmarshalText([]byte(src))
+
+	case []byte:
+		if len(src) == length {
+			return o.UnmarshalBinary(src)
+		} else {
+			return o.UnmarshalText(src)
+		}
+
+	default:
+		return fmt.Errorf("uuid.ID.Scan: cannot scan type %T into ID", pSrc)
+	}
+}
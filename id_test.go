@@ -0,0 +1,141 @@
+package uuid
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestID(t *testing.T) {
+	id := ToID(NameSpaceDNS)
+
+	assert.Equal(t, length, id.Size())
+	assert.Equal(t, One, id.Version())
+	assert.Equal(t, VariantRFC4122, id.Variant())
+	assert.Equal(t, NameSpaceDNS.String(), id.String())
+	assert.Equal(t, NameSpaceDNS, id.Immutable())
+	assert.True(t, Equal(id.Uuid(), NameSpaceDNS))
+	assert.Equal(t, id, ToID(id.Uuid()))
+	assert.Equal(t, ID{}, ToID(nil))
+	assert.Equal(t, ID{}, ToID(Nil))
+
+	keys := map[ID]bool{id: true}
+	assert.True(t, keys[ToID(NameSpaceDNS)], "ID should be usable as a map key")
+
+	u := id.Uuid()
+	u[0] = 0
+	assert.Equal(t, ToID(NameSpaceDNS), id, "Copies should not share memory")
+}
+
+func TestID_Marshal(t *testing.T) {
+	id := NewV4ID()
+
+	b, err := id.MarshalBinary()
+	assert.NoError(t, err)
+	out := ID{}
+	assert.NoError(t, out.UnmarshalBinary(b))
+	assert.Equal(t, id, out)
+	assert.Error(t, out.UnmarshalBinary(b[1:]))
+
+	text, err := id.MarshalText()
+	assert.NoError(t, err)
+	out = ID{}
+	assert.NoError(t, out.UnmarshalText(text))
+	assert.Equal(t, id, out)
+	assert.Error(t, out.UnmarshalText(text[1:]))
+
+	parsed, err := ParseID(string(text))
+	assert.NoError(t, err)
+	assert.Equal(t, id, parsed)
+}
+
+func TestID_Sql(t *testing.T) {
+	id := NewV4ID()
+
+	value, err := id.Value()
+	assert.NoError(t, err)
+
+	out := ID{}
+	assert.NoError(t, out.Scan(value))
+	assert.Equal(t, id, out)
+
+	out = ID{}
+	assert.NoError(t, out.Scan(id.String()))
+	assert.Equal(t, id, out)
+
+	out = ID{}
+	assert.NoError(t, out.Scan(id[:]))
+	assert.Equal(t, id, out)
+
+	assert.NoError(t, out.Scan(nil))
+	assert.NoError(t, out.Scan(""))
+	assert.Error(t, out.Scan(1))
+}
+
+func TestNewID_Allocations(t *testing.T) {
+	gen := newGenerator(GeneratorConfig{})
+
+	cases := map[string]func(){
+		"V1":  func() { gen.NewV1ID() },
+		"V2":  func() { gen.NewV2ID(DomainUser) },
+		"V3":  func() { NewV3ID(NameSpaceURL, Name("www.example.com")) },
+		"V4":  func() { gen.NewV4ID() },
+		"V5":  func() { NewV5ID(NameSpaceURL, Name("www.example.com")) },
+		"V6":  func() { gen.NewV6ID() },
+		"V7":  func() { gen.NewV7ID() },
+		"V4E": func() { gen.NewV4IDE() },
+		"V7E": func() { gen.NewV7IDE() },
+	}
+	if raceEnabled {
+		delete(cases, "V4")
+		delete(cases, "V7")
+		delete(cases, "V4E")
+		delete(cases, "V7E")
+	}
+	for name, fNew := range cases {
+		assert.Equal(t, float64(0), testing.AllocsPerRun(100, fNew), "%s should not allocate", name)
+	}
+}
+
+func TestNewID_Concurrent(t *testing.T) {
+	const workers, count = 8, 500
+	gen := newGenerator(GeneratorConfig{})
+
+	v1 := make([][]ID, workers)
+	v6 := make([][]ID, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < count; j++ {
+				v1[i] = append(v1[i], gen.NewV1ID())
+				v6[i] = append(v6[i], gen.NewV6ID())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for name, ids := range map[string][][]ID{"V1": v1, "V6": v6} {
+		seen := make(map[ID]bool, workers*count)
+		for _, batch := range ids {
+			for _, id := range batch {
+				assert.False(t, seen[id], "%s UUIDs should be unique", name)
+				seen[id] = true
+			}
+		}
+	}
+}
+
+func TestNewID_Version(t *testing.T) {
+	assert.Equal(t, One, NewV1ID().Version())
+	assert.Equal(t, Two, NewV2ID(DomainGroup).Version())
+	assert.Equal(t, Three, NewV3ID(NameSpaceURL, Name("www.example.com")).Version())
+	assert.Equal(t, Four, NewV4ID().Version())
+	assert.Equal(t, Five, NewV5ID(NameSpaceURL, Name("www.example.com")).Version())
+	assert.Equal(t, Six, NewV6ID().Version())
+	assert.Equal(t, Seven, NewV7ID().Version())
+
+	assert.Equal(t, NewV3(NameSpaceURL, Name("www.example.com")), NewV3ID(NameSpaceURL, Name("www.example.com")).Uuid())
+	assert.Equal(t, NewV5(NameSpaceURL, Name("www.example.com")), NewV5ID(NameSpaceURL, Name("www.example.com")).Uuid())
+}
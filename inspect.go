@@ -0,0 +1,105 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/hex"
+	"time"
+)
+
+// InspectFlag marks a notable property of an inspected UUID.
+type InspectFlag string
+
+const (
+	FlagNil           InspectFlag = "nil"             // The Nil UUID
+	FlagMax           InspectFlag = "max"             // The Max UUID
+	FlagNonRFCVariant InspectFlag = "non-rfc-variant" // A variant other than RFC4122
+	FlagRandomNode    InspectFlag = "random-node"     // A node with the multicast bit set, which is random rather than a MAC address
+)
+
+// Inspection is a breakdown of the fields of a UUID as returned by Inspect.
+// Fields which the UUID does not have are left empty so that they are not
+// included when it is encoded by encoding/json.
+type Inspection struct {
+	UUID          string        `json:"uuid"`
+	Version       Version       `json:"version"`
+	Variant       string        `json:"variant"`
+	Time          *time.Time    `json:"time,omitempty"`
+	ClockSequence *Sequence     `json:"clockSequence,omitempty"`
+	Node          string        `json:"node,omitempty"`
+	Domain        *Domain       `json:"domain,omitempty"`
+	ID            *uint32       `json:"id,omitempty"`
+	Flags         []InspectFlag `json:"flags,omitempty"`
+	Warnings      []string      `json:"warnings,omitempty"`
+}
+
+// Warnings given by Inspect
+const (
+	warnLength     = "uuid is not 16 bytes"
+	warnUnknown    = "unknown version"
+	warnFutureTime = "timestamp in the future"
+	warnZeroNode   = "node is zero"
+)
+
+// Inspect returns a breakdown of any UUID for debugging. The time, clock
+// sequence, node, domain and id are given for the versions which have them,
+// see Uuid.Time. A nil UUID is inspected as the Nil UUID.
+func Inspect(pId UUID) Inspection {
+	return inspect(pId, time.Now())
+}
+
+func inspect(pId UUID, pNow time.Time) (o Inspection) {
+	a := toArray(pId)
+	o.UUID = canonicalFormat.sprint(a[:])
+	o.Version = resolveVersion(a[versionIndex] >> 4)
+	o.Variant = variantName(variant(a[variantIndex]))
+
+	if pId != nil && len(pId.Bytes()) != length {
+		o.Warnings = append(o.Warnings, warnLength)
+	}
+
+	switch a {
+	case array{}:
+		o.Flags = append(o.Flags, FlagNil)
+		return
+	case toArray(Max):
+		o.Flags = append(o.Flags, FlagMax)
+		return
+	}
+
+	switch variant(a[variantIndex]) {
+	case VariantRFC4122:
+		if o.Version == Unknown {
+			o.Warnings = append(o.Warnings, warnUnknown)
+		}
+	default:
+		o.Flags = append(o.Flags, FlagNonRFCVariant)
+		return
+	}
+
+	if now, ok := a.time(); ok {
+		t := now.Time()
+		o.Time = &t
+		if t.After(pNow) {
+			o.Warnings = append(o.Warnings, warnFutureTime)
+		}
+	}
+	if seq, ok := a.clockSequence(); ok {
+		o.ClockSequence = &seq
+	}
+	if node, ok := a.node(); ok {
+		o.Node = hex.EncodeToString(node)
+		if node[0]&0x01 != 0 {
+			o.Flags = append(o.Flags, FlagRandomNode)
+		}
+		if bytes.Count(node, []byte{0}) == len(node) {
+			o.Warnings = append(o.Warnings, warnZeroNode)
+		}
+	}
+	if domain, ok := a.domain(); ok {
+		o.Domain = &domain
+	}
+	if id, ok := a.localID(); ok {
+		o.ID = &id
+	}
+	return
+}
@@ -0,0 +1,88 @@
+package uuid
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestInspect(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	v1, _ := Parse("c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	b, err := json.Marshal(inspect(v1, now))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"uuid": "c232ab00-9414-11ec-b3c8-9f6bdeced846",
+		"version": 1,
+		"variant": "RFC4122",
+		"time": "2022-02-22T19:22:22Z",
+		"clockSequence": 13256,
+		"node": "9f6bdeced846",
+		"flags": ["random-node"]
+	}`, string(b))
+
+	v7, _ := Parse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	b, err = json.Marshal(inspect(v7, now))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"uuid": "017f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+		"version": 7,
+		"variant": "RFC4122",
+		"time": "2022-02-22T19:22:22Z"
+	}`, string(b))
+
+	v := inspect(v7, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, []string{warnFutureTime}, v.Warnings)
+
+	v = inspect(NameSpaceDNS, now)
+	assert.Equal(t, "00c04fd430c8", v.Node)
+	assert.Empty(t, v.Flags, "A MAC address node should not be flagged")
+	assert.Empty(t, v.Warnings)
+
+	zeroNode, _ := Parse("c232ab00-9414-11ec-b3c8-000000000000")
+	assert.Equal(t, []string{warnZeroNode}, inspect(zeroNode, now).Warnings)
+
+	v2 := NewV2(DomainGroup)
+	v = Inspect(v2)
+	assert.Equal(t, Two, v.Version)
+	assert.Equal(t, DomainGroup, *v.Domain)
+	id, _ := v2.ID()
+	assert.Equal(t, id, *v.ID)
+
+	v = Inspect(NewV4())
+	assert.Equal(t, Four, v.Version)
+	assert.Nil(t, v.Time)
+	assert.Nil(t, v.ClockSequence)
+	assert.Empty(t, v.Node)
+}
+
+func TestInspect_Special(t *testing.T) {
+	v := Inspect(Nil)
+	assert.Equal(t, []InspectFlag{FlagNil}, v.Flags)
+	assert.Equal(t, Unknown, v.Version)
+
+	v = Inspect(nil)
+	assert.Equal(t, []InspectFlag{FlagNil}, v.Flags)
+	assert.Empty(t, v.Warnings)
+
+	v = Inspect(Max)
+	assert.Equal(t, []InspectFlag{FlagMax}, v.Flags)
+
+	v = Inspect(Uuid{0x6b, 0xa7})
+	assert.Equal(t, []string{warnLength}, v.Warnings)
+	assert.Equal(t, "6ba70000-0000-0000-0000-000000000000", v.UUID)
+
+	ncs := ToID(NameSpaceDNS)
+	ncs[variantIndex] &= 0x7f
+	v = Inspect(ncs)
+	assert.Equal(t, "NCS", v.Variant)
+	assert.Equal(t, []InspectFlag{FlagNonRFCVariant}, v.Flags)
+	assert.Nil(t, v.Time, "Fields of other variants should not be decoded")
+
+	unknown := ToID(NameSpaceDNS)
+	unknown[versionIndex] |= 0xf0
+	v = Inspect(unknown)
+	assert.Equal(t, []string{warnUnknown}, v.Warnings)
+}
@@ -0,0 +1,210 @@
+package uuid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// FieldKind describes the purpose of a bit field within a V8 Layout and how
+// the Builder fills it when no value has been set.
+type FieldKind uint8
+
+const (
+	FieldTime    FieldKind = iota + 1 // Unix epoch milliseconds from the Generator
+	FieldCounter                      // A per Builder counter which wraps at the field width
+	FieldNode                         // The low bits of the Generator's Node
+	FieldRandom                       // Random bits from the Generator
+)
+
+const (
+	versionBit = versionIndex * 8
+	variantBit = variantIndex * 8
+
+	// The number of bits reserved for the version and for the RFC4122 variant
+	versionBits = 4
+	variantBits = 2
+)
+
+// Field is a named run of bits within a V8 Layout. The Offset counts from the
+// most significant bit of the UUID and a Field can be at most 64 bits wide.
+type Field struct {
+	Name   string
+	Kind   FieldKind
+	Offset uint
+	Width  uint
+}
+
+func (o Field) overlaps(pOffset, pWidth uint) bool {
+	return o.Offset < pOffset+pWidth && pOffset < o.Offset+o.Width
+}
+
+// Layout describes a custom V8 UUID as a set of named bit fields. Bits not
+// covered by a Field are left as zero.
+type Layout struct {
+	fields []Field
+}
+
+// NewLayout validates the given fields and creates a Layout. Fields must not
+// overlap each other or the version and variant bits which are managed by the
+// package.
+func NewLayout(pFields ...Field) (*Layout, error) {
+	for i, v := range pFields {
+		if v.Name == "" {
+			return nil, errors.New("uuid.NewLayout: a field must have a name")
+		}
+		if v.Kind < FieldTime || v.Kind > FieldRandom {
+			return nil, fmt.Errorf("uuid.NewLayout: field %q has an unknown kind", v.Name)
+		}
+		if v.Width == 0 || v.Width > 64 {
+			return nil, fmt.Errorf("uuid.NewLayout: field %q must be between 1 and 64 bits wide", v.Name)
+		}
+		if v.Offset+v.Width > length*8 {
+			return nil, fmt.Errorf("uuid.NewLayout: field %q does not fit within %d bits", v.Name, length*8)
+		}
+		if v.overlaps(versionBit, versionBits) {
+			return nil, fmt.Errorf("uuid.NewLayout: field %q overlaps the version bits", v.Name)
+		}
+		if v.overlaps(variantBit, variantBits) {
+			return nil, fmt.Errorf("uuid.NewLayout: field %q overlaps the variant bits", v.Name)
+		}
+		for _, w := range pFields[:i] {
+			if v.Name == w.Name {
+				return nil, fmt.Errorf("uuid.NewLayout: field %q is defined twice", v.Name)
+			}
+			if v.overlaps(w.Offset, w.Width) {
+				return nil, fmt.Errorf("uuid.NewLayout: field %q overlaps field %q", v.Name, w.Name)
+			}
+		}
+	}
+	return &Layout{fields: append([]Field(nil), pFields...)}, nil
+}
+
+// Fields returns a copy of the fields which make up the Layout.
+func (o *Layout) Fields() []Field {
+	return append([]Field(nil), o.fields...)
+}
+
+// Builder returns a Builder which fills the Layout using the default package
+// Generator.
+func (o *Layout) Builder() *Builder {
+	return generator.Load().Builder(o)
+}
+
+// Builder returns a Builder which fills the given Layout using the time,
+// random and node sources of the Generator.
+func (o *Generator) Builder(pLayout *Layout) *Builder {
+	return &Builder{
+		Layout:    pLayout,
+		generator: o,
+		values:    make(map[string]uint64),
+		errs:      make(map[string]error),
+	}
+}
+
+// Builder creates V8 UUIDs from a Layout. Values given to Set are kept between
+// calls to Build so that constant fields, such as a shard id, only need to be
+// set once.
+//
+// Build is safe to call from many goroutines, however Set is not. A Builder
+// must not be used by more than one goroutine while Set is being called.
+type Builder struct {
+	*Layout
+	generator *Generator
+	values    map[string]uint64
+
+	// errs holds the error from the last call to Set for each name
+	errs map[string]error
+
+	// counter is the next value of counter fields and is guarded by the
+	// Generator's lock
+	counter uint64
+}
+
+// Set gives the named field a value. Any error, such as an unknown name or a
+// value which is too wide for the field, is returned by Build until a later
+// Set to the same field succeeds.
+func (o *Builder) Set(pName string, pValue uint64) *Builder {
+	for _, v := range o.fields {
+		if v.Name != pName {
+			continue
+		}
+		if v.Width < 64 && pValue>>v.Width != 0 {
+			o.errs[pName] = fmt.Errorf("uuid.Builder.Set: value %d is too wide for field %q", pValue, pName)
+		} else {
+			o.values[pName] = pValue
+			delete(o.errs, pName)
+		}
+		return o
+	}
+	o.errs[pName] = fmt.Errorf("uuid.Builder.Set: layout has no field %q", pName)
+	return o
+}
+
+// error returns an error from Set. Fields are checked in Layout order before
+// any unknown names.
+func (o *Builder) error() error {
+	if len(o.errs) == 0 {
+		return nil
+	}
+	for _, v := range o.fields {
+		if err := o.errs[v.Name]; err != nil {
+			return err
+		}
+	}
+	for _, err := range o.errs {
+		return err
+	}
+	return nil
+}
+
+// Build creates a new version 8 UUID from the Layout. Fields which have not
+// been set are filled by the Generator according to their kind. Counter fields
+// are incremented by each call to Build.
+func (o *Builder) Build() (Uuid, error) {
+	if err := o.error(); err != nil {
+		return nil, err
+	}
+	if err := o.generator.Init(); err != nil {
+		return nil, err
+	}
+
+	var id, random array
+	if _, err := o.generator.Random(random[:]); err != nil {
+		return nil, err
+	}
+
+	o.generator.Lock()
+	now := o.generator.Next()
+	node := o.generator.Node
+	counter := o.counter
+	o.counter++
+	o.generator.Unlock()
+
+	for _, v := range o.fields {
+		value, ok := o.values[v.Name]
+		if !ok {
+			switch v.Kind {
+			case FieldTime:
+				value = now.UnixMilli()
+			case FieldCounter:
+				value = counter
+			case FieldNode:
+				value = nodeBits(node)
+			case FieldRandom:
+				value = random.bits(v.Offset, v.Width)
+			}
+		}
+		id.setBits(v.Offset, v.Width, value)
+	}
+
+	id.setRFC4122Version(8)
+	return id[:], nil
+}
+
+// nodeBits returns the Node as a big endian integer
+func nodeBits(pNode Node) (value uint64) {
+	for _, v := range pNode {
+		value = value<<8 | uint64(v)
+	}
+	return
+}
@@ -0,0 +1,107 @@
+package uuid
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewV8(t *testing.T) {
+	payload := [length]byte{}
+	for i := range payload {
+		payload[i] = 0xff
+	}
+	id := NewV8(payload)
+
+	assert.Equal(t, Eight, id.Version())
+	assert.Equal(t, VariantRFC4122, id.Variant())
+	assert.Equal(t, "ffffffff-ffff-8fff-bfff-ffffffffffff", id.String())
+}
+
+func TestNewLayout(t *testing.T) {
+	_, err := NewLayout(
+		Field{Name: "time", Kind: FieldTime, Offset: 0, Width: 48},
+		Field{Name: "shard", Kind: FieldNode, Offset: 52, Width: 12},
+		Field{Name: "random", Kind: FieldRandom, Offset: 66, Width: 62},
+	)
+	assert.NoError(t, err)
+
+	invalid := [][]Field{
+		{{Name: "version", Kind: FieldNode, Offset: 44, Width: 8}},
+		{{Name: "variant", Kind: FieldNode, Offset: 60, Width: 5}},
+		{{Name: "", Kind: FieldNode, Offset: 0, Width: 8}},
+		{{Name: "kind", Offset: 0, Width: 8}},
+		{{Name: "wide", Kind: FieldNode, Offset: 0, Width: 65}},
+		{{Name: "empty", Kind: FieldNode, Offset: 0, Width: 0}},
+		{{Name: "outside", Kind: FieldNode, Offset: 120, Width: 16}},
+		{
+			{Name: "a", Kind: FieldNode, Offset: 0, Width: 16},
+			{Name: "b", Kind: FieldNode, Offset: 8, Width: 16},
+		},
+		{
+			{Name: "a", Kind: FieldNode, Offset: 0, Width: 16},
+			{Name: "a", Kind: FieldNode, Offset: 16, Width: 16},
+		},
+	}
+	for i, v := range invalid {
+		_, err := NewLayout(v...)
+		assert.Error(t, err, "Layout %d should be rejected", i)
+	}
+}
+
+func TestBuilder_Build(t *testing.T) {
+	now := Now()
+	gen := newGenerator(GeneratorConfig{
+		Next: func() Timestamp {
+			return now
+		},
+		Id: func() Node {
+			return Node{0x02, 0, 0, 0, 0xbe, 0xef}
+		},
+	})
+
+	layout, err := NewLayout(
+		Field{Name: "shard", Kind: FieldNode, Offset: 0, Width: 16},
+		Field{Name: "time", Kind: FieldTime, Offset: 16, Width: 32},
+		Field{Name: "type", Kind: FieldCounter, Offset: 52, Width: 12},
+		Field{Name: "random", Kind: FieldRandom, Offset: 66, Width: 62},
+	)
+	assert.NoError(t, err)
+
+	builder := gen.Builder(layout).Set("shard", 0xabcd).Set("type", 0x123)
+	id, err := builder.Build()
+	assert.NoError(t, err)
+
+	a := array{}
+	a.unmarshal(id)
+	assert.Equal(t, Eight, id.Version())
+	assert.Equal(t, VariantRFC4122, id.Variant())
+	assert.Equal(t, uint64(0xabcd), a.bits(0, 16))
+	assert.Equal(t, now.UnixMilli()&0xffffffff, a.bits(16, 32))
+	assert.Equal(t, uint64(0x123), a.bits(52, 12))
+
+	next, err := builder.Build()
+	assert.NoError(t, err)
+	assert.NotEqual(t, id, next, "Random fields should differ")
+
+	builder = gen.Builder(layout)
+	for i := uint64(0); i < 3; i++ {
+		id, err = builder.Build()
+		assert.NoError(t, err)
+		a.unmarshal(id)
+		assert.Equal(t, uint64(0xbeef), a.bits(0, 16), "An unset node field should hold the low bits of the Node")
+		assert.Equal(t, i, a.bits(52, 12), "An unset counter field should increment")
+	}
+	assert.Equal(t, uint64(0), gen.Builder(layout).counter, "Each Builder should have its own counter")
+
+	builder = gen.Builder(layout).Set("type", 0x1000)
+	_, err = builder.Build()
+	assert.Error(t, err, "Value is too wide for the field")
+
+	id, err = builder.Set("type", 0xfff).Build()
+	assert.NoError(t, err, "A later valid Set should clear the error")
+	a.unmarshal(id)
+	assert.Equal(t, uint64(0xfff), a.bits(52, 12))
+
+	_, err = gen.Builder(layout).Set("missing", 1).Build()
+	assert.Error(t, err, "Field does not exist")
+}
@@ -0,0 +1,81 @@
+package uuid
+
+import (
+	"database/sql/driver"
+)
+
+// NullUuid represents a Uuid which may be null. It implements the sql.Scanner
+// interface so it can be used as a scan destination in the same way as
+// sql.NullString. It is also encoded as null by encoding/json when it is not
+// Valid.
+type NullUuid struct {
+	Uuid  Uuid
+	Valid bool // Valid is true if Uuid is not NULL
+}
+
+// Scan implements the sql.Scanner interface. A NULL value or an empty string
+// sets Valid to false, anything else is scanned the same as Uuid.Scan.
+func (o *NullUuid) Scan(pSrc interface{}) error {
+	o.Uuid, o.Valid = nil, false
+	if pSrc == nil {
+		return nil
+	}
+	if err := o.Uuid.Scan(pSrc); err != nil {
+		return err
+	}
+	o.Valid = len(o.Uuid) != 0
+	return nil
+}
+
+// Value implements the driver.Valuer interface. NULL is returned when the
+// NullUuid is not Valid.
+func (o NullUuid) Value() (driver.Value, error) {
+	if !o.Valid {
+		return nil, nil
+	}
+	return o.Uuid.Value()
+}
+
+// MarshalJSON implements the json.Marshaler interface. A NullUuid which is
+// not Valid is encoded as null.
+func (o NullUuid) MarshalJSON() ([]byte, error) {
+	if !o.Valid {
+		return []byte(jsonNull), nil
+	}
+	return o.Uuid.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. A JSON null or an
+// empty string sets Valid to false.
+func (o *NullUuid) UnmarshalJSON(pData []byte) error {
+	var id Uuid
+	if err := id.UnmarshalJSON(pData); err != nil {
+		return err
+	}
+	o.Uuid, o.Valid = id, id != nil
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. A NullUuid
+// which is not Valid is encoded as empty text.
+func (o NullUuid) MarshalText() ([]byte, error) {
+	if !o.Valid {
+		return []byte{}, nil
+	}
+	return o.Uuid.MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Empty text
+// sets Valid to false.
+func (o *NullUuid) UnmarshalText(pUuid []byte) error {
+	if len(pUuid) == 0 {
+		o.Uuid, o.Valid = nil, false
+		return nil
+	}
+	var id Uuid
+	if err := id.UnmarshalText(pUuid); err != nil {
+		return err
+	}
+	o.Uuid, o.Valid = id, true
+	return nil
+}
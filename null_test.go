@@ -0,0 +1,131 @@
+package uuid
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func TestNullUuid_Sql(t *testing.T) {
+	stub := &stubDriver{rows: []driver.Value{
+		nil,
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		NameSpaceURL.Bytes(),
+		"",
+	}}
+	db := sql.OpenDB(stub)
+	defer db.Close()
+
+	rows, err := db.Query("SELECT id")
+	if !assert.NoError(t, err) {
+		return
+	}
+	var scanned []NullUuid
+	for rows.Next() {
+		v := NullUuid{Uuid: Uuid(NameSpaceX500), Valid: true}
+		assert.NoError(t, rows.Scan(&v))
+		scanned = append(scanned, v)
+	}
+	assert.NoError(t, rows.Err())
+
+	assert.Equal(t, []NullUuid{
+		{nil, false},
+		{Uuid(NameSpaceDNS), true},
+		{Uuid(NameSpaceURL), true},
+		{nil, false},
+	}, scanned)
+
+	_, err = db.Exec("INSERT", NullUuid{}, NullUuid{Uuid(NameSpaceDNS), true})
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.Value{nil, []byte("6ba7b810-9dad-11d1-80b4-00c04fd430c8")}, stub.args)
+
+	stub.rows = []driver.Value{"6ba7b810"}
+	var v NullUuid
+	assert.Error(t, db.QueryRow("SELECT id").Scan(&v))
+	assert.False(t, v.Valid)
+}
+
+func TestNullUuid_JSON(t *testing.T) {
+	b, err := json.Marshal([]NullUuid{{}, {Uuid(NameSpaceDNS), true}, {Uuid(NameSpaceDNS), false}})
+	assert.NoError(t, err)
+	assert.Equal(t, `[null,"6ba7b810-9dad-11d1-80b4-00c04fd430c8",null]`, string(b))
+
+	var out []NullUuid
+	assert.NoError(t, json.Unmarshal([]byte(`[null,"6ba7b810-9dad-11d1-80b4-00c04fd430c8",""]`), &out))
+	assert.Equal(t, []NullUuid{{nil, false}, {Uuid(NameSpaceDNS), true}, {nil, false}}, out)
+
+	v := NullUuid{Uuid(NameSpaceURL), true}
+	assert.Error(t, json.Unmarshal([]byte(`"6ba7b810"`), &v))
+	assert.Equal(t, NullUuid{Uuid(NameSpaceURL), true}, v, "Should be unchanged on error")
+}
+
+func TestNullUuid_Text(t *testing.T) {
+	b, err := NullUuid{}.MarshalText()
+	assert.NoError(t, err)
+	assert.Empty(t, b)
+
+	b, err = NullUuid{Uuid(NameSpaceDNS), true}.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", string(b))
+
+	v := NullUuid{}
+	assert.NoError(t, v.UnmarshalText(b))
+	assert.Equal(t, NullUuid{Uuid(NameSpaceDNS), true}, v)
+
+	assert.NoError(t, v.UnmarshalText(nil))
+	assert.Equal(t, NullUuid{}, v)
+
+	assert.Error(t, v.UnmarshalText([]byte("6ba7b810")))
+}
+
+// stubDriver is a database/sql driver connector which returns its rows, in a
+// single column, for every query and records the arguments of the last
+// statement.
+type stubDriver struct {
+	rows []driver.Value
+	args []driver.Value
+}
+
+func (o *stubDriver) Connect(context.Context) (driver.Conn, error) { return stubConn{o}, nil }
+func (o *stubDriver) Driver() driver.Driver                        { return nil }
+
+type stubConn struct{ *stubDriver }
+
+func (o stubConn) Prepare(string) (driver.Stmt, error) { return stubStmt(o), nil }
+func (o stubConn) Close() error                        { return nil }
+func (o stubConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+type stubStmt struct{ *stubDriver }
+
+func (o stubStmt) Close() error  { return nil }
+func (o stubStmt) NumInput() int { return -1 }
+
+func (o stubStmt) Exec(pArgs []driver.Value) (driver.Result, error) {
+	o.args = pArgs
+	return driver.RowsAffected(1), nil
+}
+
+func (o stubStmt) Query(pArgs []driver.Value) (driver.Rows, error) {
+	o.args = pArgs
+	return &stubRows{values: o.rows}, nil
+}
+
+type stubRows struct {
+	values []driver.Value
+}
+
+func (o *stubRows) Columns() []string { return []string{"id"} }
+func (o *stubRows) Close() error      { return nil }
+
+func (o *stubRows) Next(pDst []driver.Value) error {
+	if len(o.values) == 0 {
+		return io.EOF
+	}
+	pDst[0], o.values = o.values[0], o.values[1:]
+	return nil
+}
@@ -0,0 +1,115 @@
+package uuid
+
+import (
+	"database/sql/driver"
+	"io"
+)
+
+// Printer renders UUIDs in a fixed Format. Unlike SwitchFormat it does not
+// change how any other UUID is printed so each library or encoder can have its
+// own Printer. A Printer is immutable and safe for concurrent use. The zero
+// Printer uses FormatCanonical.
+type Printer struct {
+	compiled *CompiledFormat
+}
+
+// NewPrinter validates and compiles the Format into a Printer.
+func NewPrinter(pFormat Format) (Printer, error) {
+	f, err := CompileFormat(pFormat)
+	if err != nil {
+		return Printer{}, err
+	}
+	return Printer{f}, nil
+}
+
+func (o Printer) format() *CompiledFormat {
+	if o.compiled == nil {
+		return canonicalFormat
+	}
+	return o.compiled
+}
+
+// Sprint returns the UUID rendered in the Printer's Format.
+func (o Printer) Sprint(pId UUID) string {
+	return o.format().Sprint(pId)
+}
+
+// AppendTo appends the UUID rendered in the Printer's Format to pDst.
+func (o Printer) AppendTo(pDst []byte, pId UUID) []byte {
+	return o.format().AppendTo(pDst, pId)
+}
+
+// AppendID appends the ID rendered in the Printer's Format to pDst without
+// allocating if pDst has enough capacity.
+func (o Printer) AppendID(pDst []byte, pId ID) []byte {
+	return o.format().AppendID(pDst, pId)
+}
+
+// AppendUuid is the same as AppendID for a Uuid.
+func (o Printer) AppendUuid(pDst []byte, pId Uuid) []byte {
+	return o.format().AppendUuid(pDst, pId)
+}
+
+// Wrap pairs the UUID with the Printer so that it is encoded in the Printer's
+// Format by encoding/json, encoding/xml and database/sql.
+func (o Printer) Wrap(pId UUID) Formatted {
+	return Formatted{ID: ToID(pId), Printer: o}
+}
+
+// Formatted is an ID which is encoded using its own Printer rather than the
+// package Format set by SwitchFormat. Use it to choose a Format for each
+// field or encoder. It can be decoded from the Encoding of its Printer's
+// Format or any format accepted by Parse.
+type Formatted struct {
+	ID      ID
+	Printer Printer
+}
+
+// String returns the ID rendered by the Printer.
+func (o Formatted) String() string {
+	return o.Printer.Sprint(o.ID)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface using the
+// Printer.
+func (o Formatted) MarshalText() ([]byte, error) {
+	f := o.Printer.format()
+	return f.appendUuid(make([]byte, 0, f.size), o.ID[:]), nil
+}
+
+// WriteTo implements the io.WriterTo interface using the Printer.
+func (o Formatted) WriteTo(pWriter io.Writer) (int64, error) {
+	n, err := o.Printer.format().Fprint(pWriter, o.ID)
+	return int64(n), err
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts
+// the Encoding of the Printer's Format and any format accepted by Parse.
+func (o *Formatted) UnmarshalText(pUuid []byte) error {
+	id, err := o.Printer.format().parse(string(pUuid))
+	if err == nil {
+		o.ID = ID(id)
+	}
+	return err
+}
+
+// Value implements the driver.Valuer interface using the Printer.
+func (o Formatted) Value() (driver.Value, error) {
+	return o.String(), nil
+}
+
+// Scan implements the sql.Scanner interface in the same way as ID.Scan but
+// text is decoded using UnmarshalText.
+func (o *Formatted) Scan(pSrc interface{}) error {
+	switch src := pSrc.(type) {
+	case string:
+		if src != "" {
+			return o.UnmarshalText([]byte(src))
+		}
+	case []byte:
+		if len(src) != 0 && len(src) != length {
+			return o.UnmarshalText(src)
+		}
+	}
+	return o.ID.Scan(pSrc)
+}
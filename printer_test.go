@@ -0,0 +1,85 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestPrinter(t *testing.T) {
+	p, err := NewPrinter(FormatCanonicalCurly)
+	assert.NoError(t, err)
+	assert.Equal(t, "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}", p.Sprint(NameSpaceDNS))
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", NameSpaceDNS.String(), "Printer should not change the package Format")
+
+	p, err = NewPrinter("%X:%X:%X:%X:%X")
+	assert.NoError(t, err)
+	assert.Equal(t, "6BA7B810:9DAD:11D1:80B4:00C04FD430C8", p.Sprint(NameSpaceDNS))
+
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", Printer{}.Sprint(NameSpaceDNS))
+
+	_, err = NewPrinter("%x")
+	assert.Error(t, err)
+}
+
+func TestFormatted(t *testing.T) {
+	urn, _ := NewPrinter(FormatUrn)
+	hex, _ := NewPrinter(FormatHex)
+
+	v := struct {
+		A Formatted
+		B Formatted
+		C Uuid
+	}{urn.Wrap(NameSpaceDNS), hex.Wrap(NameSpaceURL), Uuid(NameSpaceOID)}
+
+	b, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"A":"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8","B":"6ba7b8119dad11d180b400c04fd430c8","C":"6ba7b812-9dad-11d1-80b4-00c04fd430c8"}`, string(b))
+
+	out := struct {
+		A Formatted
+		B Formatted
+	}{}
+	assert.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, ToID(NameSpaceDNS), out.A.ID)
+	assert.Equal(t, ToID(NameSpaceURL), out.B.ID)
+
+	value, err := v.A.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8", value)
+
+	f := Formatted{}
+	assert.NoError(t, f.Scan(value))
+	assert.Equal(t, ToID(NameSpaceDNS), f.ID)
+
+	buf := new(bytes.Buffer)
+	n, err := v.B.WriteTo(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(32), n)
+	assert.Equal(t, "6ba7b8119dad11d180b400c04fd430c8", buf.String())
+}
+
+func TestSwitchFormat_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				SwitchFormat(FormatCanonicalBracket)
+				SwitchFormat(FormatCanonical)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s := NameSpaceDNS.String()
+				assert.Contains(t, s, "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+			}
+		}()
+	}
+	wg.Wait()
+	SwitchFormat(FormatCanonical)
+}
@@ -0,0 +1,6 @@
+//go:build race
+
+package uuid
+
+// The race detector makes crypto/rand.Read allocate
+const raceEnabled = true
@@ -46,10 +46,11 @@ type Saver interface {
 // in conjunction with uuid.Init. You may implement the uuid.Saver interface
 // or use the provided uuid.Saver's from the uuid/savers package.
 func RegisterSaver(pSaver Saver) {
-	generator.Do(func() {
-		defer generator.init()
-		generator.Lock()
-		defer generator.Unlock()
-		generator.Saver = pSaver
+	gen := generator.Load()
+	gen.Do(func() {
+		defer gen.init()
+		gen.Lock()
+		defer gen.Unlock()
+		gen.Saver = pSaver
 	})
 }
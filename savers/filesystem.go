@@ -8,7 +8,7 @@ package savers
 import (
 	"encoding/gob"
 	"github.com/twinj/uuid"
-	"log"
+	"log/slog"
 	"os"
 	"path"
 	"time"
@@ -16,6 +16,8 @@ import (
 
 var _ uuid.Saver = &FileSystemSaver{}
 
+var discardLogger = slog.New(slog.DiscardHandler)
+
 // This implements the Saver interface for UUIDs
 type FileSystemSaver struct {
 	// A file to save the state to
@@ -25,14 +27,21 @@ type FileSystemSaver struct {
 	// Preferred location for the store
 	Path string
 
-	// Whether to log each save
+	// Whether to log each save at the Info level
 	Report bool
 
+	// Logger receives structured records of file errors and saves. The
+	// default is silent.
+	Logger *slog.Logger
+
 	// The amount of time between each save call
 	time.Duration
 
 	// The next time to save
 	uuid.Timestamp
+
+	// The last store given to Save which has not yet been saved
+	pending *uuid.Store
 }
 
 func (o *FileSystemSaver) Save(pStore uuid.Store) {
@@ -41,13 +50,28 @@ func (o *FileSystemSaver) Save(pStore uuid.Store) {
 		err := o.openAndDo(o.encode, &pStore)
 		if err == nil {
 			if o.Report {
-				log.Printf("UUID Saved State Storage: %s", pStore)
+				o.logger().Info("uuid.FileSystemSaver.Save: saved state",
+					slog.String("path", o.Path), slog.Any("timestamp", pStore.Timestamp),
+					slog.Any("sequence", pStore.Sequence))
 			}
 		}
 		o.Timestamp = pStore.Add(o.Duration)
+		o.pending = nil
+	} else {
+		o.pending = &pStore
 	}
 }
 
+// Close saves the last store given to Save if it was held back by the save
+// schedule. It is called by uuid.Generator.Close.
+func (o *FileSystemSaver) Close() (err error) {
+	if o.pending != nil {
+		err = o.openAndDo(o.encode, o.pending)
+		o.pending = nil
+	}
+	return
+}
+
 func (o *FileSystemSaver) Read() (err error, store uuid.Store) {
 	store = uuid.Store{}
 	gob.Register(&uuid.Store{})
@@ -64,10 +88,12 @@ func (o *FileSystemSaver) Read() (err error, store uuid.Store) {
 			// If new encode blank store
 			err = o.openAndDo(o.encode, &store)
 			if err == nil {
-				log.Println("uuid.FileSystemSaver created", o.Path)
+				o.logger().Info("uuid.FileSystemSaver.Read: created store", slog.String("path", o.Path))
+				return
 			}
 		}
-		log.Println("uuid.FileSystemSaver.Read: error will autogenerate", err)
+		o.logger().Warn("uuid.FileSystemSaver.Read: could not create store, state will be generated",
+			slog.String("path", o.Path), slog.Any("error", err))
 		return
 	}
 
@@ -81,11 +107,20 @@ func (o *FileSystemSaver) openAndDo(fDo func(*uuid.Store), pStore *uuid.Store) (
 	if err == nil {
 		fDo(pStore)
 	} else {
-		log.Println("uuid.FileSystemSaver.openAndDo error:", err)
+		o.logger().Error("uuid.FileSystemSaver: could not open store",
+			slog.String("path", o.Path), slog.Any("error", err))
 	}
 	return
 }
 
+// logger returns the Logger or a silent one if it is not set
+func (o *FileSystemSaver) logger() *slog.Logger {
+	if o.Logger == nil {
+		return discardLogger
+	}
+	return o.Logger
+}
+
 func (o *FileSystemSaver) encode(pStore *uuid.Store) {
 	// ensure reader state is ready for use
 	enc := gob.NewEncoder(o.file)
@@ -6,8 +6,10 @@ package savers
  ***************/
 
 import (
+	"bytes"
 	"github.com/stretchr/testify/assert"
 	"github.com/twinj/uuid"
+	"log/slog"
 	"os"
 	"path"
 	"runtime"
@@ -130,3 +132,44 @@ func TestFileSystemSaver_SaveAndRead(t *testing.T) {
 	assert.Equal(t, store.Node, saved.Node)
 
 }
+
+func TestFileSystemSaver_Close(t *testing.T) {
+
+	saver := setupFileSystemStateSaver(path.Join(os.TempDir(), "github.com.twinj.uuid.generator-"+uuid.NewV4().String()+".gob"), false)
+	defer os.Remove(saver.Path)
+
+	// Read is always called first
+	saver.Read()
+
+	first := uuid.Store{Timestamp: 1, Sequence: 2, Node: []byte{0xff, 0xaa, 0x33, 0x44, 0x55, 0x66}}
+	saver.Save(first)
+
+	last := uuid.Store{Timestamp: 2, Sequence: 3, Node: []byte{0xff, 0xaa, 0x33, 0x44, 0x55, 0x66}}
+	saver.Save(last)
+
+	_, saved := saver.Read()
+	assert.Equal(t, first.Sequence, saved.Sequence, "Save should be held back by the schedule")
+
+	assert.NoError(t, saver.Close())
+
+	_, saved = saver.Read()
+	assert.Equal(t, last.Timestamp, saved.Timestamp, "Close should save the held back store")
+	assert.Equal(t, last.Sequence, saved.Sequence)
+
+	assert.NoError(t, saver.Close(), "Close should do nothing when there is nothing to save")
+}
+
+func TestFileSystemSaver_Logger(t *testing.T) {
+	var buf bytes.Buffer
+	saver := setupFileSystemStateSaver(path.Join(os.TempDir(), "github.com.twinj.uuid.generator-"+uuid.NewV4().String()+".gob"), true)
+	saver.Logger = slog.New(slog.NewTextHandler(&buf, nil))
+	defer os.Remove(saver.Path)
+
+	saver.Read()
+	assert.Contains(t, buf.String(), "uuid.FileSystemSaver.Read: created store")
+
+	buf.Reset()
+	saver.Save(uuid.Store{Timestamp: 1, Sequence: 2, Node: []byte{0xde, 0xad, 0xbe, 0xef, 0x55, 0x66}})
+	assert.Contains(t, buf.String(), "sequence=2")
+	assert.NotContains(t, buf.String(), "deadbeef", "The node id should never be logged")
+}
@@ -0,0 +1,77 @@
+package uuid
+
+import (
+	"database/sql/driver"
+)
+
+// The SQL types choose how an ID is stored by database/sql. Convert an ID to
+// one of them when passing it as an argument or scanning it, for example
+// db.QueryRow(q, uuid.SQLBinary(id)).Scan((*uuid.SQLBinary)(&id)). Scan
+// accepts the stored form of the type and also, like ID.Scan, any text
+// accepted by Parse.
+
+// SQLBinary stores an ID as its 16 octets in network byte order, such as in a
+// MySQL BINARY(16) column.
+type SQLBinary ID
+
+// SQLOrderedBinary stores an ID as 16 octets with the time high and time low
+// fields swapped, the same as UUID_TO_BIN(id, 1) in MySQL. It keeps indexes
+// on V1 UUIDs in time order. Other versions are swapped in the same way.
+type SQLOrderedBinary ID
+
+// SQLText stores an ID in the canonical format regardless of the Format set
+// by SwitchFormat.
+type SQLText ID
+
+// Value implements the driver.Valuer interface
+func (o SQLBinary) Value() (driver.Value, error) {
+	return o[:], nil
+}
+
+// Scan implements the sql.Scanner interface
+func (o *SQLBinary) Scan(pSrc interface{}) error {
+	return (*ID)(o).Scan(pSrc)
+}
+
+// Value implements the driver.Valuer interface
+func (o SQLOrderedBinary) Value() (driver.Value, error) {
+	b := orderedBinary(ID(o))
+	return b[:], nil
+}
+
+// Scan implements the sql.Scanner interface
+func (o *SQLOrderedBinary) Scan(pSrc interface{}) error {
+	if src, ok := pSrc.([]byte); ok && len(src) == length {
+		*o = SQLOrderedBinary(unorderedBinary(ID(src)))
+		return nil
+	}
+	return (*ID)(o).Scan(pSrc)
+}
+
+// Value implements the driver.Valuer interface
+func (o SQLText) Value() (driver.Value, error) {
+	return canonicalFormat.sprint(o[:]), nil
+}
+
+// Scan implements the sql.Scanner interface
+func (o *SQLText) Scan(pSrc interface{}) error {
+	return (*ID)(o).Scan(pSrc)
+}
+
+// Moves time_hi_and_version to the front followed by time_mid and time_low.
+func orderedBinary(pId ID) (o ID) {
+	copy(o[0:2], pId[6:8])
+	copy(o[2:4], pId[4:6])
+	copy(o[4:8], pId[0:4])
+	copy(o[8:], pId[8:])
+	return
+}
+
+// Reverses orderedBinary.
+func unorderedBinary(pId ID) (o ID) {
+	copy(o[0:4], pId[4:8])
+	copy(o[4:6], pId[2:4])
+	copy(o[6:8], pId[0:2])
+	copy(o[8:], pId[8:])
+	return
+}
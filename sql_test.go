@@ -0,0 +1,106 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSQLBinary(t *testing.T) {
+	id := ToID(NameSpaceDNS)
+
+	value, err := SQLBinary(id).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, id[:], value)
+
+	var out SQLBinary
+	assert.NoError(t, out.Scan(value))
+	assert.Equal(t, id, ID(out))
+
+	out = SQLBinary{}
+	assert.NoError(t, out.Scan("6ba7b810-9dad-11d1-80b4-00c04fd430c8"), "Should scan text")
+	assert.Equal(t, id, ID(out))
+}
+
+// Vector from the MySQL documentation of UUID_TO_BIN
+func TestSQLOrderedBinary(t *testing.T) {
+	id, _ := ParseID("6ccd780c-baba-1026-9564-5b8c656024db")
+	expect, _ := ParseHex("1026baba6ccd780c95645b8c656024db")
+
+	value, err := SQLOrderedBinary(id).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(expect), value)
+
+	var out SQLOrderedBinary
+	assert.NoError(t, out.Scan(value))
+	assert.Equal(t, id, ID(out))
+
+	out = SQLOrderedBinary{}
+	assert.NoError(t, out.Scan("6ccd780c-baba-1026-9564-5b8c656024db"), "Text should not be swapped")
+	assert.Equal(t, id, ID(out))
+
+	now := Now()
+	gen := newGenerator(GeneratorConfig{
+		Next: func() Timestamp {
+			now = now.Add(time.Hour)
+			return now
+		},
+	})
+	var last []byte
+	for i := 0; i < 100; i++ {
+		v1 := gen.NewV1ID()
+		value, _ := SQLOrderedBinary(v1).Value()
+		if last != nil {
+			assert.True(t, string(last) < string(value.([]byte)), "V1 UUIDs should sort by time")
+		}
+		last = value.([]byte)
+	}
+}
+
+func TestSQLText(t *testing.T) {
+	defer SwitchFormat(FormatCanonical)
+	SwitchFormat(FormatUrn)
+
+	id := ToID(NameSpaceDNS)
+	value, err := SQLText(id).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", value, "Should ignore SwitchFormat")
+
+	var out SQLText
+	assert.NoError(t, out.Scan(value))
+	assert.Equal(t, id, ID(out))
+}
+
+func TestSQL_RoundTrip(t *testing.T) {
+	stub := &stubDriver{}
+	db := sql.OpenDB(stub)
+	defer db.Close()
+
+	id := ToID(NameSpaceURL)
+	for _, v := range []struct {
+		arg  driver.Valuer
+		dest interface{}
+	}{
+		{SQLBinary(id), new(SQLBinary)},
+		{SQLOrderedBinary(id), new(SQLOrderedBinary)},
+		{SQLText(id), new(SQLText)},
+	} {
+		_, err := db.Exec("INSERT", v.arg)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		stub.rows = stub.args
+		assert.NoError(t, db.QueryRow("SELECT id").Scan(v.dest))
+
+		switch dest := v.dest.(type) {
+		case *SQLBinary:
+			assert.Equal(t, id, ID(*dest))
+		case *SQLOrderedBinary:
+			assert.Equal(t, id, ID(*dest))
+		case *SQLText:
+			assert.Equal(t, id, ID(*dest))
+		}
+	}
+}
@@ -47,6 +47,12 @@ func (o Timestamp) Time() time.Time {
 	return time.Unix(0, int64((o-gregorianToUNIXOffset)*100)).UTC()
 }
 
+// Converts UUID Timestamp to the number of milliseconds since the Unix epoch
+// as used by V7 UUIDs
+func (o Timestamp) UnixMilli() uint64 {
+	return uint64(o-gregorianToUNIXOffset) / 1e4
+}
+
 // Returns the timestamp as modified by the duration
 func (o Timestamp) Add(pDuration time.Duration) Timestamp {
 	return o + Timestamp(pDuration/100)
@@ -0,0 +1,177 @@
+package uuid
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Prefix names the type of a TypedID. Implement it on an empty struct for
+// each type of ID:
+//
+//	type UserPrefix struct{}
+//
+//	func (UserPrefix) Prefix() string { return "user" }
+//
+//	type UserID = uuid.TypedID[UserPrefix]
+//
+// A prefix must follow the TypeID specification. It can have at most 63
+// characters from a to z and the underscore, and must not start or end with
+// an underscore. An empty prefix is allowed.
+type Prefix interface {
+	Prefix() string
+}
+
+// TypedID is an ID which is printed with its type as a prefix, such as
+// user_01h455vb4pex5vsknk084sn02q. It follows the TypeID specification so
+// the text form can be exchanged with other TypeID implementations. As each
+// Prefix is its own Go type a TypedID for one type cannot be used where
+// another is expected.
+//
+// The text form is the prefix, an underscore and the ID in 26 characters of
+// lower case Crockford base32. The underscore is left out when the prefix is
+// empty. The zero TypedID holds the Nil ID.
+type TypedID[P Prefix] struct {
+	id ID
+}
+
+// typeIDEncoding is Crockford base32 in lower case. TypeID does not allow
+// upper case or the Crockford aliases.
+var typeIDEncoding = newEncoding("0123456789abcdefghjkmnpqrstvwxyz", 26)
+
+const maxPrefixLength = 63
+
+// NewTypedID generates a TypedID holding a new V7 UUID from the default
+// package Generator. It panics where NewV7 would panic.
+func NewTypedID[P Prefix]() TypedID[P] {
+	return TypedID[P]{generator.Load().NewV7ID()}
+}
+
+// NewTypedIDE is the same as NewTypedID but returns the error from the CPRNG
+// as NewV7E does.
+func NewTypedIDE[P Prefix]() (TypedID[P], error) {
+	id, err := generator.Load().NewV7IDE()
+	return TypedID[P]{id}, err
+}
+
+// ToTypedID gives a TypedID for the UUID. Any version can be used, however
+// the TypeID specification recommends V7.
+func ToTypedID[P Prefix](pId UUID) TypedID[P] {
+	return TypedID[P]{ToID(pId)}
+}
+
+// ParseTypedID decodes the text form of a TypedID. The prefix must be the
+// prefix of P. Errors are returned as a *ParseError.
+func ParseTypedID[P Prefix](pTypeId string) (o TypedID[P], err error) {
+	prefix, suffix, base := o.Prefix(), pTypeId, 0
+	if err = validatePrefix(prefix); err != nil {
+		return
+	}
+	if prefix != "" {
+		i := 0
+		for i < len(prefix) && i < len(pTypeId) && pTypeId[i] == prefix[i] {
+			i++
+		}
+		if i < len(prefix) || i == len(pTypeId) || pTypeId[i] != '_' {
+			return o, &ParseError{pTypeId, i, ReasonBadPrefix}
+		}
+		suffix, base = pTypeId[i+1:], i+1
+	}
+
+	id, err := typeIDEncoding.decodeString(suffix)
+	if err != nil {
+		err.(*ParseError).Input = pTypeId
+		err.(*ParseError).Offset += base
+		return
+	}
+	o.id = ID(id)
+	return
+}
+
+func validatePrefix(pPrefix string) error {
+	if len(pPrefix) > maxPrefixLength {
+		return fmt.Errorf("uuid.TypedID: prefix %q is longer than %d characters", pPrefix, maxPrefixLength)
+	}
+	for i := 0; i < len(pPrefix); i++ {
+		c := pPrefix[i]
+		if c == '_' && (i == 0 || i == len(pPrefix)-1) {
+			return fmt.Errorf("uuid.TypedID: prefix %q must not start or end with an underscore", pPrefix)
+		}
+		if (c < 'a' || c > 'z') && c != '_' {
+			return fmt.Errorf("uuid.TypedID: prefix %q may only contain a to z and underscores", pPrefix)
+		}
+	}
+	return nil
+}
+
+// Prefix returns the type prefix given by P.
+func (o TypedID[P]) Prefix() string {
+	var p P
+	return p.Prefix()
+}
+
+// ID returns the ID without its prefix.
+func (o TypedID[P]) ID() ID {
+	return o.id
+}
+
+// String returns the text form of the TypedID.
+func (o TypedID[P]) String() string {
+	return string(o.appendText(nil))
+}
+
+func (o TypedID[P]) appendText(pDst []byte) []byte {
+	if prefix := o.Prefix(); prefix != "" {
+		pDst = append(append(pDst, prefix...), '_')
+	}
+	a := array(o.id)
+	return typeIDEncoding.appendEncode(pDst, &a)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. It returns an
+// error if the prefix of P is invalid.
+func (o TypedID[P]) MarshalText() ([]byte, error) {
+	prefix := o.Prefix()
+	if err := validatePrefix(prefix); err != nil {
+		return nil, err
+	}
+	return o.appendText(make([]byte, 0, len(prefix)+1+typeIDEncoding.width)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface in the same
+// way as ParseTypedID.
+func (o *TypedID[P]) UnmarshalText(pTypeId []byte) error {
+	id, err := ParseTypedID[P](string(pTypeId))
+	if err == nil {
+		*o = id
+	}
+	return err
+}
+
+// Value implements the driver.Valuer interface. The TypedID is stored in its
+// text form.
+func (o TypedID[P]) Value() (driver.Value, error) {
+	b, err := o.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface. Text must be in the form given
+// by Value. A 16 byte slice is taken as the binary ID so that a TypedID can
+// also be kept in a binary column.
+func (o *TypedID[P]) Scan(pSrc interface{}) error {
+	switch src := pSrc.(type) {
+	case nil:
+		return nil
+	case string:
+		return o.UnmarshalText([]byte(src))
+	case []byte:
+		if len(src) == length {
+			return o.id.UnmarshalBinary(src)
+		}
+		return o.UnmarshalText(src)
+	default:
+		return fmt.Errorf("uuid.TypedID.Scan: cannot scan type %T into TypedID", pSrc)
+	}
+}
@@ -0,0 +1,163 @@
+package uuid
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type prefixUser struct{}
+
+func (prefixUser) Prefix() string { return "user" }
+
+type prefixTest struct{}
+
+func (prefixTest) Prefix() string { return "prefix" }
+
+type prefixUnderscore struct{}
+
+func (prefixUnderscore) Prefix() string { return "pre_fix" }
+
+type prefixNone struct{}
+
+func (prefixNone) Prefix() string { return "" }
+
+type prefixInvalid struct{}
+
+func (prefixInvalid) Prefix() string { return "User" }
+
+// Vectors from the TypeID specification
+func TestParseTypedID(t *testing.T) {
+	valid := []struct {
+		input string
+		uuid  string
+	}{
+		{"00000000000000000000000000", "00000000-0000-0000-0000-000000000000"},
+		{"00000000000000000000000001", "00000000-0000-0000-0000-000000000001"},
+		{"0000000000000000000000000a", "00000000-0000-0000-0000-00000000000a"},
+		{"0000000000000000000000000g", "00000000-0000-0000-0000-000000000010"},
+		{"00000000000000000000000010", "00000000-0000-0000-0000-000000000020"},
+		{"7zzzzzzzzzzzzzzzzzzzzzzzzz", "ffffffff-ffff-ffff-ffff-ffffffffffff"},
+	}
+	for _, v := range valid {
+		id, err := ParseTypedID[prefixNone](v.input)
+		assert.NoError(t, err, "Should parse %s", v.input)
+		assert.Equal(t, v.uuid, Formatter(id.ID(), FormatCanonical))
+		assert.Equal(t, v.input, id.String())
+	}
+
+	id, err := ParseTypedID[prefixTest]("prefix_0123456789abcdefghjkmnpqrs")
+	assert.NoError(t, err)
+	assert.Equal(t, "0110c853-1d09-52d8-d73e-1194e95b5f19", Formatter(id.ID(), FormatCanonical))
+
+	id, err = ParseTypedID[prefixTest]("prefix_01h455vb4pex5vsknk084sn02q")
+	assert.NoError(t, err)
+	assert.Equal(t, "01890a5d-ac96-774b-bcce-b302099a8057", Formatter(id.ID(), FormatCanonical))
+	assert.Equal(t, Seven, id.ID().Version())
+
+	underscore, err := ParseTypedID[prefixUnderscore]("pre_fix_00000000000000000000000000")
+	assert.NoError(t, err)
+	assert.Equal(t, "pre_fix", underscore.Prefix())
+
+	invalid := []struct {
+		input  string
+		offset int
+		reason ParseErrorReason
+	}{
+		{"PREFIX_00000000000000000000000000", 0, ReasonBadPrefix},
+		{"prefix00000000000000000000000000", 6, ReasonBadPrefix},
+		{"prefix_", 7, ReasonBadLength},
+		{"prefix", 6, ReasonBadPrefix},
+		{"user_01h455vb4pex5vsknk084sn02q", 0, ReasonBadPrefix},
+		{"prefix_8zzzzzzzzzzzzzzzzzzzzzzzzz", 7, ReasonOverflow},
+		{"prefix_0123456789ABCDEFGHJKMNPQRS", 17, ReasonBadDigit},
+		{"prefix_o0000000000000000000000000", 7, ReasonBadDigit},
+		{"prefix_00000000000000000000000000_", 33, ReasonBadLength},
+	}
+	for _, v := range invalid {
+		_, err := ParseTypedID[prefixTest](v.input)
+		var parseErr *ParseError
+		if !assert.True(t, errors.As(err, &parseErr), "Should not parse %s", v.input) {
+			continue
+		}
+		assert.Equal(t, v.input, parseErr.Input)
+		assert.Equal(t, v.offset, parseErr.Offset, "Offset for %s", v.input)
+		assert.Equal(t, v.reason, parseErr.Reason, "Reason for %s", v.input)
+	}
+
+	_, err = ParseTypedID[prefixNone]("_00000000000000000000000000")
+	assert.Error(t, err, "An empty prefix should have no separator")
+
+	_, err = ParseTypedID[prefixInvalid]("User_00000000000000000000000000")
+	assert.Error(t, err, "An invalid prefix should never parse")
+}
+
+func TestNewTypedIDE(t *testing.T) {
+	id, err := NewTypedIDE[prefixUser]()
+	assert.NoError(t, err)
+	assert.Equal(t, Seven, id.ID().Version())
+
+	assert.NoError(t, ResetDefault())
+	defer ResetDefault()
+	failure := errors.New("no entropy")
+	RegisterGenerator(GeneratorConfig{
+		Random: func([]byte) (int, error) {
+			return 0, failure
+		},
+	})
+	assert.NotPanics(t, func() {
+		id, err = NewTypedIDE[prefixUser]()
+	})
+	assert.Equal(t, failure, err)
+	assert.True(t, id.ID().IsZero())
+}
+
+func TestValidatePrefix(t *testing.T) {
+	for _, v := range []string{"", "user", "pre_fix", strings.Repeat("a", 63)} {
+		assert.NoError(t, validatePrefix(v), "Should be valid %q", v)
+	}
+	for _, v := range []string{"_user", "user_", "User", "user1", "us-er", strings.Repeat("a", 64)} {
+		assert.Error(t, validatePrefix(v), "Should be invalid %q", v)
+	}
+}
+
+func TestTypedID(t *testing.T) {
+	id := NewTypedID[prefixUser]()
+	assert.Equal(t, Seven, id.ID().Version())
+	assert.True(t, strings.HasPrefix(id.String(), "user_"))
+	assert.Len(t, id.String(), len("user_")+26)
+
+	v := ToTypedID[prefixUser](NameSpaceDNS)
+	assert.Equal(t, ToID(NameSpaceDNS), v.ID())
+
+	b, err := json.Marshal(struct{ User TypedID[prefixUser] }{v})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"User":"`+v.String()+`"}`, string(b))
+
+	out := struct{ User TypedID[prefixUser] }{}
+	assert.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, v, out.User)
+
+	// A TypedID of another type should be rejected
+	order := struct{ User TypedID[prefixTest] }{}
+	assert.Error(t, json.Unmarshal(b, &order))
+
+	value, err := v.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, v.String(), value)
+
+	var scanned TypedID[prefixUser]
+	assert.NoError(t, scanned.Scan(value))
+	assert.Equal(t, v, scanned)
+
+	scanned = TypedID[prefixUser]{}
+	assert.NoError(t, scanned.Scan(NameSpaceDNS.Bytes()))
+	assert.Equal(t, v, scanned)
+
+	assert.Error(t, scanned.Scan(1))
+
+	_, err = ToTypedID[prefixInvalid](NameSpaceDNS).MarshalText()
+	assert.Error(t, err)
+}
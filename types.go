@@ -1,9 +1,10 @@
 package uuid
 
 import (
+	"bytes"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
-	"strings"
 )
 
 const (
@@ -20,6 +21,23 @@ func (o *array) unmarshal(pData []byte) {
 	copy(o[:], pData)
 }
 
+// Copies any UUID into an array. A nil UUID is equivalent to the Nil UUID and
+// a UUID with fewer than 16 bytes is padded with zeros the same as New.
+func toArray(pId UUID) (o array) {
+	switch id := pId.(type) {
+	case nil:
+	case ID:
+		o = array(id)
+	case Immutable:
+		copy(o[:], id)
+	case Uuid:
+		copy(o[:], id)
+	default:
+		o.unmarshal(pId.Bytes())
+	}
+	return
+}
+
 // Set the three most significant bits (bits 0, 1 and 2) of the
 // sequenceHiAndVariant equivalent in the array to ReservedRFC4122.
 func (o *array) setRFC4122Version(pVersion uint8) {
@@ -29,6 +47,30 @@ func (o *array) setRFC4122Version(pVersion uint8) {
 	o[variantIndex] |= VariantRFC4122
 }
 
+// Writes the least significant pWidth bits of pValue into the array starting
+// at bit pOffset counted from the most significant bit.
+func (o *array) setBits(pOffset, pWidth uint, pValue uint64) {
+	for i := uint(0); i < pWidth; i++ {
+		bit := pOffset + i
+		mask := byte(0x80 >> (bit % 8))
+		if pValue>>(pWidth-1-i)&1 == 1 {
+			o[bit/8] |= mask
+		} else {
+			o[bit/8] &^= mask
+		}
+	}
+}
+
+// Reads pWidth bits from the array starting at bit pOffset counted from the
+// most significant bit.
+func (o *array) bits(pOffset, pWidth uint) (value uint64) {
+	for i := uint(0); i < pWidth; i++ {
+		bit := pOffset + i
+		value = value<<1 | uint64(o[bit/8]>>(7-bit%8)&1)
+	}
+	return
+}
+
 // **************************************************** Default implementation
 
 var _ UUID = &Uuid{}
@@ -42,13 +84,21 @@ func (o Uuid) Size() int {
 	return length
 }
 
-// Version returns the uuid.Version of the Uuid
+// Version returns the uuid.Version of the Uuid. A nil or short Uuid is
+// Unknown.
 func (o Uuid) Version() Version {
+	if len(o) <= versionIndex {
+		return Unknown
+	}
 	return resolveVersion(o[versionIndex] >> 4)
 }
 
-// Variant returns the implementation variant of the Uuid
+// Variant returns the implementation variant of the Uuid. A nil or short Uuid
+// has the same variant as the Nil UUID.
 func (o Uuid) Variant() uint8 {
+	if len(o) <= variantIndex {
+		return variant(0)
+	}
 	return variant(o[variantIndex])
 }
 
@@ -61,7 +111,7 @@ func (o Uuid) Bytes() []byte {
 // String returns the canonical string representation of the UUID or the
 // uuid.Format the package is set to via uuid.SwitchFormat
 func (o Uuid) String() string {
-	return formatUuid(o, printFormat)
+	return currentFormat().sprint(o)
 }
 
 // **************************************************** Implementations
@@ -71,39 +121,87 @@ func (o Uuid) MarshalBinary() ([]byte, error) {
 	return o.Bytes(), nil
 }
 
-// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. Any
+// existing value is replaced with a new copy of the bytes so that other
+// slices which share its memory are not changed.
 func (o *Uuid) UnmarshalBinary(pBytes []byte) error {
 	if len(pBytes) != o.Size() {
 		return fmt.Errorf("uuid.Uuid.UnmarshalBinary:  invalid length")
 	}
-	if len(*o) != 0 {
-		panic("uuid.Uuid.UnmarshalBinary: you must use an empty or new Uuid to unmarhal bytes")
-	}
-	*o = append(*o, pBytes...)
+	*o = append(Uuid(nil), pBytes...)
 	return nil
 }
 
 // MarshalText implements the encoding.TextMarshaler interface. It will marshal
 // text into one of the known formats, if you have changed to a custom Format
-// the text
+// the text is marshalled in the canonical format.
+//
+// An encoded Format, such as FormatBase58, is also marshalled as canonical
+// text so that UnmarshalText can always read it back. This means String and
+// MarshalText differ after SwitchFormat to an encoded Format. Only a Formatted
+// ID, from Printer.Wrap, is marshalled as encoded text.
 func (o Uuid) MarshalText() ([]byte, error) {
-	f := FormatCanonical
-	if defaultFormats[printFormat] {
-		f = printFormat
+	f := currentFormat()
+	if !defaultFormats[f.format] || f.encoding != nil {
+		f = canonicalFormat
 	}
-	return []byte(strings.ToLower(string(format(o.Bytes(), string(f))))), nil
+	b := f.appendUuid(make([]byte, 0, f.size), o)
+	return bytes.ToLower(b), nil
 }
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface. It will
-// support any text that MarshalText can produce.
+// support any text that MarshalText can produce and any format accepted by
+// Parse. Encoded text is not accepted, use ParseEncoded or a Printer.
 func (o *Uuid) UnmarshalText(pUuid []byte) error {
 	id, err := parse(string(pUuid))
 	if err == nil {
-		o.UnmarshalBinary(id)
+		o.UnmarshalBinary(id[:])
 	}
 	return err
 }
 
+// MarshalJSON implements the json.Marshaler interface. A nil or empty Uuid is
+// encoded as null, otherwise the text from MarshalText is encoded as a JSON
+// string.
+func (o Uuid) MarshalJSON() ([]byte, error) {
+	if len(o) == 0 {
+		return []byte(jsonNull), nil
+	}
+	b, err := o.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	// MarshalText only produces characters which need no escaping
+	return append(append(append(make([]byte, 0, len(b)+2), '"'), b...), '"'), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. A JSON null or an
+// empty string gives a nil Uuid and any other string is decoded the same as
+// UnmarshalText. Any existing value is replaced.
+func (o *Uuid) UnmarshalJSON(pData []byte) error {
+	if string(pData) == jsonNull {
+		*o = nil
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(pData, &s); err != nil {
+		return fmt.Errorf("uuid.Uuid.UnmarshalJSON: %s", err)
+	}
+	if s == "" {
+		*o = nil
+		return nil
+	}
+	return o.UnmarshalText([]byte(s))
+}
+
+const jsonNull = "null"
+
+// IsZero reports whether the Uuid is nil, empty or the Nil UUID. It lets the
+// omitzero option of encoding/json leave out unset Uuids.
+func (o Uuid) IsZero() bool {
+	return toArray(o) == array{}
+}
+
 // Value implements the driver.Valuer interface
 func (o Uuid) Value() (value driver.Value, err error) {
 	if len(o) == 0 {
@@ -153,11 +251,17 @@ func (o Immutable) Size() int {
 
 // Version returns the uuid.Version of the Uuid
 func (o Immutable) Version() Version {
+	if len(o) <= versionIndex {
+		return Unknown
+	}
 	return resolveVersion(o[versionIndex] >> 4)
 }
 
 // Variant returns the implementation variant of the Uuid
 func (o Immutable) Variant() uint8 {
+	if len(o) <= variantIndex {
+		return variant(0)
+	}
 	return variant(o[variantIndex])
 }
 
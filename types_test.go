@@ -0,0 +1,83 @@
+package uuid
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUuid_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(Uuid(NameSpaceDNS))
+	assert.NoError(t, err)
+	assert.Equal(t, `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`, string(b))
+
+	b, err = json.Marshal(Uuid(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(b), "A nil Uuid should be null")
+
+	v := struct {
+		A Uuid
+		B Uuid `json:",omitempty"`
+		C Uuid `json:",omitzero"`
+		D ID   `json:",omitzero"`
+		E ID
+	}{}
+	b, err = json.Marshal(v)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"A":null,"E":"00000000-0000-0000-0000-000000000000"}`, string(b))
+
+	v.C, v.D = Uuid(Nil), ToID(Nil)
+	b, err = json.Marshal(v)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"A":null,"E":"00000000-0000-0000-0000-000000000000"}`, string(b), "The Nil UUID should be zero")
+}
+
+func TestUuid_UnmarshalJSON(t *testing.T) {
+	id := Uuid(NameSpaceURL)
+	shared := id
+	assert.NoError(t, json.Unmarshal([]byte(`"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`), &id), "Should overwrite a filled Uuid")
+	assert.Equal(t, Uuid(NameSpaceDNS), id)
+	assert.Equal(t, Uuid(NameSpaceURL), shared, "Should not change shared memory")
+
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &id))
+	assert.Nil(t, id)
+
+	id = Uuid(NameSpaceURL)
+	assert.NoError(t, json.Unmarshal([]byte(`""`), &id))
+	assert.Nil(t, id)
+
+	id = Uuid(NameSpaceURL)
+	assert.NoError(t, json.Unmarshal([]byte(`"6ba7b810\u002d9dad-11d1-80b4-00c04fd430c8"`), &id), "Should decode JSON escapes")
+	assert.Equal(t, Uuid(NameSpaceDNS), id)
+
+	id = Uuid(NameSpaceURL)
+	assert.Error(t, json.Unmarshal([]byte(`"6ba7b810"`), &id))
+	assert.Error(t, json.Unmarshal([]byte(`16`), &id))
+	assert.Equal(t, Uuid(NameSpaceURL), id, "Should be unchanged on error")
+
+	v := struct{ A, B Uuid }{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"A":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","B":null}`), &v))
+	assert.Equal(t, Uuid(NameSpaceDNS), v.A)
+	assert.Nil(t, v.B)
+}
+
+func TestUuid_UnmarshalBinary(t *testing.T) {
+	id := Uuid(NameSpaceURL)
+	assert.NotPanics(t, func() {
+		assert.NoError(t, id.UnmarshalBinary(NameSpaceDNS.Bytes()))
+	})
+	assert.Equal(t, Uuid(NameSpaceDNS), id)
+	assert.Error(t, id.UnmarshalBinary([]byte{1, 2}))
+
+	assert.NoError(t, id.UnmarshalText([]byte(NameSpaceX500.String())))
+	assert.Equal(t, Uuid(NameSpaceX500), id)
+}
+
+func TestUuid_IsZero(t *testing.T) {
+	assert.True(t, Uuid(nil).IsZero())
+	assert.True(t, Uuid{}.IsZero())
+	assert.True(t, Uuid(Nil).IsZero())
+	assert.False(t, Uuid(NameSpaceDNS).IsZero())
+	assert.True(t, ID{}.IsZero())
+	assert.False(t, ToID(NameSpaceDNS).IsZero())
+}
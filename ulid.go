@@ -0,0 +1,165 @@
+package uuid
+
+import (
+	"errors"
+)
+
+// A ULID is a 48 bit Unix epoch millisecond timestamp followed by 80 bits of
+// random data. It has the same 16 octets as a UUID and so can be held in a
+// Uuid or ID. Its text form is 26 characters of Crockford base32, which is
+// the Base32 Encoding.
+//
+// A ULID is not a valid UUID as it has no version or variant bits. Use
+// ULIDToV7 to make one.
+
+// ulidTimeLength is the number of octets holding the timestamp
+const ulidTimeLength = 6
+
+// ParseULID decodes a 26 character ULID string. Errors are returned as a
+// *ParseError.
+func ParseULID(pUlid string) (Uuid, error) {
+	return Base32.DecodeString(pUlid)
+}
+
+// ULIDString returns the UUID encoded as a ULID string.
+func (o Uuid) ULIDString() string {
+	return Base32.EncodeToString(o)
+}
+
+// ULIDString returns the ID encoded as a ULID string.
+func (o ID) ULIDString() string {
+	return Base32.EncodeToString(o)
+}
+
+// NewULID generates a new ULID using the default package Generator.
+func NewULID() Uuid {
+	return generator.Load().NewULID()
+}
+
+// NewMonotonicULID generates a new monotonic ULID using the default package
+// Generator.
+func NewMonotonicULID() Uuid {
+	return generator.Load().NewMonotonicULID()
+}
+
+// NewULIDE is the same as NewULID but returns the error from the CPRNG along
+// with nil.
+func NewULIDE() (Uuid, error) {
+	return generator.Load().NewULIDE()
+}
+
+// NewMonotonicULIDE is the same as NewMonotonicULID but returns the error from
+// the CPRNG along with nil.
+func NewMonotonicULIDE() (Uuid, error) {
+	return generator.Load().NewMonotonicULIDE()
+}
+
+// NewULID generates a new ULID from a timestamp taken from the Generator's
+// Next function and 80 bits of data taken from its Random function. ULIDs
+// made within the same millisecond are in no particular order. If Random
+// fails nil is returned and the error is handled as for NewV7.
+func (o *Generator) NewULID() Uuid {
+	id, err := o.retry("uuid.ULID", o.HandleError, o.newULID)
+	if err != nil {
+		return nil
+	}
+	return id[:]
+}
+
+// NewULIDE is the same as NewULID but returns the error from Random along
+// with nil. HandleError is applied as for NewV4E.
+func (o *Generator) NewULIDE() (Uuid, error) {
+	id, err := o.retry("uuid.ULID", o.handleErrorE(), o.newULID)
+	if err != nil {
+		return nil, err
+	}
+	return id[:], nil
+}
+
+// NewMonotonicULID is the same as NewULID except that ULIDs made within the
+// same millisecond are the previous ULID plus one so they always sort in
+// order of creation. If the random part overflows it is carried into the
+// timestamp.
+func (o *Generator) NewMonotonicULID() Uuid {
+	id, err := o.retry("uuid.ULID", o.HandleError, o.newMonotonicULID)
+	if err != nil {
+		return nil
+	}
+	return id[:]
+}
+
+// NewMonotonicULIDE is the same as NewMonotonicULID but returns the error from
+// Random along with nil. HandleError is applied as for NewV4E.
+func (o *Generator) NewMonotonicULIDE() (Uuid, error) {
+	id, err := o.retry("uuid.ULID", o.handleErrorE(), o.newMonotonicULID)
+	if err != nil {
+		return nil, err
+	}
+	return id[:], nil
+}
+
+func (o *Generator) newULID() (id array, err error) {
+	if _, err = o.readRandom(id[ulidTimeLength:]); err != nil {
+		return
+	}
+
+	o.Lock()
+	defer o.Unlock()
+
+	setUnixMilli(&id, o.Next().UnixMilli())
+	return
+}
+
+func (o *Generator) newMonotonicULID() (id array, err error) {
+	if _, err = o.readRandom(id[ulidTimeLength:]); err != nil {
+		return
+	}
+
+	o.Lock()
+	defer o.Unlock()
+
+	now := o.Next().UnixMilli()
+	if last := unixMilli(o.ulid[:]); now <= last {
+		if !incrementULID(&o.ulid) {
+			return o.ulid, nil
+		}
+		now = last + 1
+	}
+	setUnixMilli(&id, now)
+	o.ulid = id
+	return
+}
+
+// Adds one to the random part of the ULID. It returns true if the random part
+// overflowed.
+func incrementULID(pId *array) bool {
+	for i := length - 1; i >= ulidTimeLength; i-- {
+		pId[i]++
+		if pId[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ULIDToV7 converts a ULID into a version 7 UUID. The timestamp is kept and
+// the version and variant bits are written over 6 of the 80 random bits, so
+// the conversion cannot be reversed exactly. V7ToULID of the result gives a
+// ULID with the same timestamp which sorts in the same place among ULIDs of
+// other milliseconds.
+func ULIDToV7(pId UUID) Uuid {
+	o := toArray(pId)
+	o.setRFC4122Version(7)
+	return o[:]
+}
+
+// V7ToULID converts a version 7 UUID into a ULID. Both have a 48 bit
+// millisecond timestamp in the first six octets so the octets are copied
+// unchanged and the version and variant bits become part of the random data.
+func V7ToULID(pId UUID) (Uuid, error) {
+	if pId == nil || pId.Version() != Seven {
+		return nil, errors.New("uuid.V7ToULID: can only convert a version 7 UUID")
+	}
+	o := toArray(pId)
+	return o[:], nil
+}
@@ -0,0 +1,117 @@
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const ulidExample = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+
+func TestParseULID(t *testing.T) {
+	id, err := ParseULID(ulidExample)
+	assert.NoError(t, err)
+	assert.Equal(t, "01563e3a-b5d3-d676-4c61-efb99302bd5b", Formatter(id, FormatCanonical))
+	assert.Equal(t, uint64(1469922850259), unixMilli(id))
+	assert.Equal(t, ulidExample, id.ULIDString())
+	assert.Equal(t, ulidExample, ToID(id).ULIDString())
+
+	id, err = ParseULID("01arz3ndektsv4rrffq69g5fav")
+	assert.NoError(t, err)
+	assert.Equal(t, ulidExample, id.ULIDString(), "Should be case insensitive")
+
+	_, err = ParseULID("81ARZ3NDEKTSV4RRFFQ69G5FAV")
+	assert.True(t, errors.Is(err, ErrInvalidFormat), "Should not overflow 128 bits")
+
+	_, err = ParseULID(ulidExample[1:])
+	assert.True(t, errors.Is(err, ErrInvalidFormat))
+}
+
+func TestGenerator_NewULID(t *testing.T) {
+	now := Now()
+	gen := newGenerator(GeneratorConfig{
+		Next: func() Timestamp {
+			now = now.Add(time.Millisecond)
+			return now
+		},
+	})
+
+	last := gen.NewULID()
+	assert.Equal(t, now.UnixMilli(), unixMilli(last))
+	for i := 0; i < 100; i++ {
+		id := gen.NewULID()
+		assert.Equal(t, -1, bytes.Compare(last, id), "ULIDs should sort by time")
+		assert.True(t, last.ULIDString() < id.ULIDString(), "ULID strings should sort by time")
+		last = id
+	}
+
+	gen = newGenerator(GeneratorConfig{
+		Random: func([]byte) (int, error) {
+			return 0, errors.New("no entropy")
+		},
+		HandleError: func(error) bool {
+			return false
+		},
+	})
+	assert.Nil(t, gen.NewULID())
+	assert.Nil(t, gen.NewMonotonicULID())
+}
+
+func TestGenerator_NewMonotonicULID(t *testing.T) {
+	now := Now()
+	gen := newGenerator(GeneratorConfig{
+		Next: func() Timestamp {
+			return now
+		},
+	})
+
+	last := gen.NewMonotonicULID()
+	for i := 0; i < 1000; i++ {
+		id := gen.NewMonotonicULID()
+		assert.Equal(t, unixMilli(last), unixMilli(id))
+		if !assert.Equal(t, -1, bytes.Compare(last, id), "ULIDs should sort within a millisecond") {
+			return
+		}
+		last = id
+	}
+
+	for i := ulidTimeLength; i < length; i++ {
+		gen.ulid[i] = 0xff
+	}
+	id := gen.NewMonotonicULID()
+	assert.Equal(t, now.UnixMilli()+1, unixMilli(id), "Overflow should carry into the timestamp")
+	assert.Equal(t, -1, bytes.Compare(last, id))
+}
+
+func TestULIDToV7(t *testing.T) {
+	ulid, _ := ParseULID(ulidExample)
+
+	id := ULIDToV7(ulid)
+	assert.Equal(t, Seven, id.Version())
+	assert.Equal(t, VariantRFC4122, id.Variant())
+	assert.Equal(t, unixMilli(ulid), unixMilli(id))
+	assert.Equal(t, Uuid(ulid), mustParseULID(t, ulidExample), "Input should not be changed")
+
+	back, err := V7ToULID(id)
+	assert.NoError(t, err)
+	assert.Equal(t, unixMilli(ulid), unixMilli(back))
+	assert.Equal(t, id, back)
+
+	v7 := NewV7()
+	back, err = V7ToULID(v7)
+	assert.NoError(t, err)
+	assert.Equal(t, v7, ULIDToV7(back), "A V7 UUID should survive the round trip")
+
+	_, err = V7ToULID(NameSpaceDNS)
+	assert.Error(t, err)
+	_, err = V7ToULID(nil)
+	assert.Error(t, err)
+}
+
+func mustParseULID(t *testing.T, pUlid string) Uuid {
+	id, err := ParseULID(pUlid)
+	assert.NoError(t, err)
+	return id
+}
@@ -1,6 +1,10 @@
-// This package provides RFC4122 and DCE 1.1 UUIDs.
+// This package provides RFC4122, RFC9562 and DCE 1.1 UUIDs.
 //
-// Use NewV1, NewV2, NewV3, NewV4, NewV5, for generating new UUIDs.
+// Use NewV1, NewV2, NewV3, NewV4, NewV5, NewV6, NewV7 for generating new UUIDs.
+//
+// Use NewV8 or a Layout to create custom vendor specific UUIDs.
+//
+// Use ToV6 and ToV1 to convert between time based UUIDs.
 //
 // Use New([]byte), NewHex(string), and Parse(string) for
 // creating UUIDs from existing data.
@@ -25,14 +29,15 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
-	"hash"
-	"log"
-	"regexp"
+	"strings"
 )
 
 const (
 	Nil Immutable = "\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"
 
+	// Max is the RFC9562 Max UUID with all bits set.
+	Max Immutable = "\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff"
+
 	// The following standard UUIDs are for use with V3 or V5 UUIDs.
 	NameSpaceDNS  Immutable = "k\xa7\xb8\x10\x9d\xad\x11р\xb4\x00\xc0O\xd40\xc8"
 	NameSpaceURL  Immutable = "k\xa7\xb8\x11\x9d\xad\x11р\xb4\x00\xc0O\xd40\xc8"
@@ -76,22 +81,49 @@ func New(pData []byte) Uuid {
 }
 
 // NewHex creates a UUID from a hex string
-// Will panic if hex string is invalid use Parse otherwise.
+// Will panic if hex string is invalid use ParseHex or Parse otherwise.
 func NewHex(pUuid string) Uuid {
 	return Uuid(fromHex(pUuid))
 }
 
+// ParseHex creates a UUID from a string of exactly 32 hex digits. It is the
+// non panicking alternative to NewHex. Any error is a *ParseError.
+func ParseHex(pUuid string) (Uuid, error) {
+	if len(pUuid) != hexLength {
+		offset := len(pUuid)
+		if offset > hexLength {
+			offset = hexLength
+		}
+		return nil, &ParseError{pUuid, offset, ReasonBadLength}
+	}
+	id := array{}
+	for i := range id {
+		if j := decodeHexByte(&id[i], pUuid, i*2); j >= 0 {
+			return nil, badDigit(pUuid, pUuid, 0, j)
+		}
+	}
+	return id[:], nil
+}
+
 const (
-	// Pattern used to parse string representation of the UUID.
-	// Current one allows to parse string where only one opening
-	// or closing bracket or any of the hyphens are optional.
-	// It is only used to extract the main bytes to create a UUID,
-	// so these imperfections are of no consequence.
-	hexPattern = `^(urn\:uuid\:)?[\{\(\[]?([[:xdigit:]]{8})-?([[:xdigit:]]{4})-?([1-5][[:xdigit:]]{3})-?([[:xdigit:]]{4})-?([[:xdigit:]]{12})[\]\}\)]?$`
+	urnPrefix = "urn:uuid:"
+
+	hexLength = length * 2
 )
 
 var (
-	parseUUIDRegex = regexp.MustCompile(hexPattern)
+	// ErrInvalidFormat is returned when a string is not in any of the
+	// formats accepted by Parse. Parse wraps it in a *ParseError which
+	// describes where and why the string is invalid.
+	ErrInvalidFormat = errors.New("uuid.Parse: invalid string format this is probably not a UUID")
+
+	// ErrUnknownVersion is returned by ParseStrict when the version is not
+	// one which is known to the package.
+	ErrUnknownVersion = errors.New("uuid.ParseStrict: unknown version")
+
+	// ErrInvalidVariant is returned by ParseStrict when the variant is not
+	// RFC4122.
+	ErrInvalidVariant = errors.New("uuid.ParseStrict: variant is not RFC4122")
 )
 
 // Parse creates a UUID from a valid string representation.
@@ -103,17 +135,147 @@ var (
 //		[6ba7b814-9dad-11d1-80b4-00c04fd430c8]
 //		(6ba7b814-9dad-11d1-80b4-00c04fd430c8)
 //
+// Parse is permissive and accepts any version and variant. Use ParseStrict to
+// only accept UUIDs with a known version and the RFC4122 variant.
 func Parse(pUuid string) (Uuid, error) {
 	id, err := parse(pUuid)
-	return Uuid(id), err
+	if err != nil {
+		return nil, err
+	}
+	return id[:], nil
+}
+
+// ParseStrict creates a UUID from a valid string representation in the same
+// formats as Parse. It will return ErrUnknownVersion if the version is not
+// known to the package and ErrInvalidVariant if the variant is not RFC4122.
+// The Nil and Max UUIDs are always accepted.
+func ParseStrict(pUuid string) (Uuid, error) {
+	id, err := Parse(pUuid)
+	if err != nil {
+		return nil, err
+	}
+	if Equal(id, Nil) || Equal(id, Max) {
+		return id, nil
+	}
+	if id.Version() == Unknown {
+		return nil, ErrUnknownVersion
+	}
+	if id.Variant() != VariantRFC4122 {
+		return nil, ErrInvalidVariant
+	}
+	return id, nil
+}
+
+// parse decodes any of the formats accepted by Parse directly into an array.
+// An optional urn:uuid: prefix may be followed by an optional pair of matching
+// brackets around 32 hex digits, either without hyphens or with all four
+// hyphens in their canonical places. Any error is a *ParseError.
+func parse(pUuid string) (id array, err error) {
+	s, base := pUuid, 0
+
+	// A colon within the length of the prefix means a URN was intended
+	if i := strings.IndexByte(s, ':'); i >= 0 && i < len(urnPrefix) {
+		for i = 0; i < len(urnPrefix); i++ {
+			if i == len(s) || toLower(s[i]) != urnPrefix[i] {
+				return id, &ParseError{pUuid, i, ReasonBadURNPrefix}
+			}
+		}
+		s, base = s[len(urnPrefix):], len(urnPrefix)
+	}
+
+	if len(s) > 0 {
+		last := len(s) - 1
+		if closing := closingBracket(s[0]); closing != 0 {
+			if last == 0 || s[last] != closing {
+				return id, &ParseError{pUuid, base + last, ReasonMismatchedBrackets}
+			}
+			s, base = s[1:last], base+1
+		} else if s[last] == '}' || s[last] == ')' || s[last] == ']' {
+			return id, &ParseError{pUuid, base + last, ReasonMismatchedBrackets}
+		}
+	}
+
+	switch len(s) {
+	case hexLength:
+		for i := range id {
+			if j := decodeHexByte(&id[i], s, i*2); j >= 0 {
+				return id, badDigit(pUuid, s, base, j)
+			}
+		}
+	case canonicalLength:
+		for i, j := 0, 0; i < length; i, j = i+1, j+2 {
+			if j == 8 || j == 13 || j == 18 || j == 23 {
+				if s[j] != '-' {
+					return id, &ParseError{pUuid, base + j, ReasonMisplacedHyphen}
+				}
+				j++
+			}
+			if k := decodeHexByte(&id[i], s, j); k >= 0 {
+				return id, badDigit(pUuid, s, base, k)
+			}
+		}
+	default:
+		offset := len(s)
+		if offset > canonicalLength {
+			offset = canonicalLength
+		}
+		return id, &ParseError{pUuid, base + offset, ReasonBadLength}
+	}
+	return id, nil
 }
 
-func parse(pUuid string) ([]byte, error) {
-	md := parseUUIDRegex.FindStringSubmatch(pUuid)
-	if md == nil {
-		return nil, errors.New("uuid.Parse: invalid string format this is probably not a UUID")
+func toLower(pChar byte) byte {
+	if 'A' <= pChar && pChar <= 'Z' {
+		return pChar + 'a' - 'A'
 	}
-	return fromHex(md[2] + md[3] + md[4] + md[5] + md[6]), nil
+	return pChar
+}
+
+func closingBracket(pOpening byte) byte {
+	switch pOpening {
+	case '{':
+		return '}'
+	case '(':
+		return ')'
+	case '[':
+		return ']'
+	}
+	return 0
+}
+
+// Creates the error for an invalid digit at index pIndex of the body pBody
+// which begins at offset pBase of the input.
+func badDigit(pInput, pBody string, pBase, pIndex int) error {
+	if pBody[pIndex] == '-' {
+		return &ParseError{pInput, pBase + pIndex, ReasonMisplacedHyphen}
+	}
+	return &ParseError{pInput, pBase + pIndex, ReasonBadHexDigit}
+}
+
+// xvalues maps each ASCII hex digit to its value, all other bytes map to 0xff.
+var xvalues = func() (table [256]byte) {
+	for i := range table {
+		table[i] = 0xff
+	}
+	for i := 0; i < len(hexTable); i++ {
+		table[hexTable[i]] = byte(i)
+		table[hexUpperTable[i]] = byte(i)
+	}
+	return
+}()
+
+// Decodes the two hex digits at index pIndex of pSrc into pDst. It returns
+// the index of the first invalid digit or -1 on success.
+func decodeHexByte(pDst *byte, pSrc string, pIndex int) int {
+	high, low := xvalues[pSrc[pIndex]], xvalues[pSrc[pIndex+1]]
+	if high == 0xff {
+		return pIndex
+	}
+	if low == 0xff {
+		return pIndex + 1
+	}
+	*pDst = high<<4 | low
+	return -1
 }
 
 func fromHex(pUuid string) []byte {
@@ -127,86 +289,212 @@ func fromHex(pUuid string) []byte {
 // NewV1 generates a new RFC4122 version 1 UUID based on a 60 bit timestamp and
 // node ID.
 func NewV1() Uuid {
-	return generator.NewV1()
+	return generator.Load().NewV1()
+}
+
+// NewV1ID is the same as NewV1 but returns an ID without allocating.
+func NewV1ID() ID {
+	return generator.Load().NewV1ID()
+}
+
+// NewV1E is the same as NewV1 but returns nil and the error if the default
+// Generator could not be initialised.
+func NewV1E() (Uuid, error) {
+	return generator.Load().NewV1E()
 }
 
 // NewV2 generates a new DCE Security version UUID based on a 60 bit timestamp,
 // node id and POSIX UID.
 func NewV2(pDomain Domain) Uuid {
-	return generator.NewV2(pDomain)
+	return generator.Load().NewV2(pDomain)
+}
+
+// NewV2ID is the same as NewV2 but returns an ID without allocating.
+func NewV2ID(pDomain Domain) ID {
+	return generator.Load().NewV2ID(pDomain)
+}
+
+// NewV2E is the same as NewV2 but returns nil and the error if the default
+// Generator could not be initialised.
+func NewV2E(pDomain Domain) (Uuid, error) {
+	return generator.Load().NewV2E(pDomain)
 }
 
 // NewV3 generates a new RFC4122 version 3 UUID based on the MD5 hash on a
 // namespace UUID and any type which implements the UniqueName interface
-// for the name. For strings and slices cast to a Name type
+// for the name. For strings and slices cast to a Name type. A nil namespace is
+// equivalent to the Nil UUID.
 func NewV3(pNamespace UUID, pNames ...UniqueName) Uuid {
-	o := array{}
-	copy(o[:], digest(md5.New(), pNamespace.Bytes(), pNames...))
-	o.setRFC4122Version(3)
+	o := NewV3ID(pNamespace, pNames...)
 	return o[:]
 }
 
+// NewV3ID is the same as NewV3 but returns an ID. It does not allocate unless
+// the names are longer than 240 bytes.
+func NewV3ID(pNamespace UUID, pNames ...UniqueName) ID {
+	var buf [nameBufferSize]byte
+	o := array(md5.Sum(digest(buf[:0], pNamespace, pNames)))
+	o.setRFC4122Version(3)
+	return ID(o)
+}
+
 // NewV4 generates a new RFC4122 version 4 UUID a cryptographically secure
 // random UUID.
 func NewV4() Uuid {
-	o, err := v4()
-	if err == nil {
-		return o[:]
-	}
-	generator.err = err
-	log.Printf("uuid.V4: There was an error getting random bytes [%s]\n", err)
-	if ok := generator.HandleError(err); ok {
-		o, err = v4()
-		if err == nil {
-			return o[:]
-		}
-		generator.err = err
-	}
-	return nil
+	return generator.Load().NewV4()
 }
 
-func v4() (o array, err error) {
-	generator.err = nil
-	_, err = generator.Random(o[:])
-	o.setRFC4122Version(4)
-	return
+// NewV4ID is the same as NewV4 but returns an ID without allocating. It
+// panics where NewV4 would panic and otherwise returns the Nil ID where NewV4
+// would return nil.
+func NewV4ID() ID {
+	return generator.Load().NewV4ID()
+}
+
+// NewV4IDE is the same as NewV4E but returns an ID without allocating.
+func NewV4IDE() (ID, error) {
+	return generator.Load().NewV4IDE()
+}
+
+// NewV4E is the same as NewV4 but returns the error from the CPRNG along with
+// nil. Unlike Error the error belongs to this call.
+func NewV4E() (Uuid, error) {
+	return generator.Load().NewV4E()
 }
 
 // NewV5 generates an RFC4122 version 5 UUID based on the SHA-1 hash of a
-// namespace UUID and a unique name.
+// namespace UUID and a unique name. A nil namespace is equivalent to the Nil
+// UUID.
 func NewV5(pNamespace UUID, pNames ...UniqueName) Uuid {
+	o := NewV5ID(pNamespace, pNames...)
+	return o[:]
+}
+
+// NewV5ID is the same as NewV5 but returns an ID. It does not allocate unless
+// the names are longer than 240 bytes.
+func NewV5ID(pNamespace UUID, pNames ...UniqueName) ID {
+	var buf [nameBufferSize]byte
+	sum := sha1.Sum(digest(buf[:0], pNamespace, pNames))
 	o := array{}
-	copy(o[:], digest(sha1.New(), pNamespace.Bytes(), pNames...))
+	copy(o[:], sum[:])
 	o.setRFC4122Version(5)
+	return ID(o)
+}
+
+// NewV6 generates a new RFC9562 version 6 UUID based on a 60 bit timestamp and
+// node ID. It is a V1 UUID with the timestamp reordered so that it sorts by
+// time of creation.
+func NewV6() Uuid {
+	return generator.Load().NewV6()
+}
+
+// NewV6ID is the same as NewV6 but returns an ID without allocating.
+func NewV6ID() ID {
+	return generator.Load().NewV6ID()
+}
+
+// NewV6E is the same as NewV6 but returns nil and the error if the default
+// Generator could not be initialised.
+func NewV6E() (Uuid, error) {
+	return generator.Load().NewV6E()
+}
+
+// NewV7 generates a new RFC9562 version 7 UUID based on a 48 bit Unix epoch
+// millisecond timestamp and random data. V7 UUIDs are time-ordered and are
+// well suited for use as database keys.
+func NewV7() Uuid {
+	return generator.Load().NewV7()
+}
+
+// NewV7ID is the same as NewV7 but returns an ID without allocating. It
+// panics where NewV7 would panic.
+func NewV7ID() ID {
+	return generator.Load().NewV7ID()
+}
+
+// NewV7IDE is the same as NewV7E but returns an ID without allocating.
+func NewV7IDE() (ID, error) {
+	return generator.Load().NewV7IDE()
+}
+
+// NewV7E is the same as NewV7 but returns the error from the CPRNG along with
+// nil.
+func NewV7E() (Uuid, error) {
+	return generator.Load().NewV7E()
+}
+
+// NewV8 creates a RFC9562 version 8 UUID from a custom payload. Only the
+// version and variant bits are set, all other bits are taken as given. Use a
+// Layout to build a payload from named bit fields.
+func NewV8(pPayload [length]byte) Uuid {
+	o := array(pPayload)
+	o.setRFC4122Version(8)
 	return o[:]
 }
 
-func digest(pHash hash.Hash, pName []byte, pNames ...UniqueName) []byte {
+// ToV6 converts a version 1 UUID into a version 6 UUID. The timestamp, clock
+// sequence and node are kept so the conversion can be reversed with ToV1.
+func ToV6(pId UUID) (Uuid, error) {
+	if pId == nil || pId.Version() != One {
+		return nil, errors.New("uuid.ToV6: can only convert a version 1 UUID")
+	}
+	a := toArray(pId)
+	b := a[:]
+	now := timestampV1(b)
+
+	id := array{}
+	makeUuid(&id,
+		uint32(now>>28),
+		uint16(now>>12),
+		uint16(now&0x0fff),
+		binary.BigEndian.Uint16(b[8:10]),
+		b[10:])
+	id.setRFC4122Version(6)
+	return id[:], nil
+}
+
+// ToV1 converts a version 6 UUID into a version 1 UUID. The timestamp, clock
+// sequence and node are kept so the conversion can be reversed with ToV6.
+func ToV1(pId UUID) (Uuid, error) {
+	if pId == nil || pId.Version() != Six {
+		return nil, errors.New("uuid.ToV1: can only convert a version 6 UUID")
+	}
+	a := toArray(pId)
+	b := a[:]
+	now := timestampV6(b)
+
+	id := array{}
+	makeUuid(&id,
+		uint32(now),
+		uint16(now>>32),
+		uint16(now>>48),
+		binary.BigEndian.Uint16(b[8:10]),
+		b[10:])
+	id.setRFC4122Version(1)
+	return id[:], nil
+}
+
+// The size of the stack buffer used to hash a namespace and names
+const nameBufferSize = 256
+
+// digest appends the namespace and names to pDst ready for hashing.
+func digest(pDst []byte, pNamespace UUID, pNames []UniqueName) []byte {
+	ns := toArray(pNamespace)
+	pDst = append(pDst, ns[:]...)
 	for _, v := range pNames {
-		pName = append(pName, v.String()...)
+		pDst = append(pDst, v.String()...)
 	}
-	pHash.Write(pName)
-	return pHash.Sum(nil)
+	return pDst
 }
 
 // Compare returns an integer comparing two UUIDs lexicographically.
 // The result will be 0 if pId==pId2, -1 if pId < pId2, and +1 if pId > pId2.
-// A nil argument is equivalent to the Nil UUID.
+// A nil argument is equivalent to the Nil UUID and a short argument is padded
+// with zeros.
 func Compare(pId, pId2 UUID) int {
 
-	var b1, b2 []byte
-
-	if pId == nil {
-		b1 = []byte(Nil)
-	} else {
-		b1 = pId.Bytes()
-	}
-
-	if pId2 == nil {
-		b2 = []byte(Nil)
-	} else {
-		b2 = pId2.Bytes()
-	}
+	a1, a2 := toArray(pId), toArray(pId2)
+	b1, b2 := a1[:], a2[:]
 
 	tl1 := binary.BigEndian.Uint32(b1[:4])
 	tl2 := binary.BigEndian.Uint32(b2[:4])
@@ -244,9 +532,17 @@ func Compare(pId, pId2 UUID) int {
 	return bytes.Compare(b1[8:], b2[8:])
 }
 
-// Compares whether each UUID is the same
+// Compares whether each UUID is the same. A nil UUID is equal to an empty one.
 func Equal(p1, p2 UUID) bool {
-	return bytes.Equal(p1.Bytes(), p2.Bytes())
+	return bytes.Equal(uuidBytes(p1), uuidBytes(p2))
+}
+
+// uuidBytes returns the bytes of the UUID or nil if it is nil
+func uuidBytes(pId UUID) []byte {
+	if pId == nil {
+		return nil
+	}
+	return pId.Bytes()
 }
 
 // Name is a string which implements UniqueName and satisfies the Stringer
@@ -0,0 +1,291 @@
+package uuid
+
+import (
+	"encoding/hex"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+// Test vectors from RFC9562 Appendix A
+const (
+	rfc9562V1 = "c232ab00-9414-11ec-b3c8-9f6bdeced846"
+	rfc9562V6 = "1ec9414c-232a-6b00-b3c8-9f6bdeced846"
+)
+
+func TestToV6(t *testing.T) {
+	v1, err := Parse(rfc9562V1)
+	assert.NoError(t, err)
+
+	v6, err := ToV6(v1)
+	assert.NoError(t, err)
+	assert.Equal(t, rfc9562V6, v6.String())
+	assert.Equal(t, timestampV1(v1), timestampV6(v6))
+
+	_, err = ToV6(v6)
+	assert.Error(t, err, "Should only convert V1 UUIDs")
+}
+
+func TestToV1(t *testing.T) {
+	v6, err := Parse(rfc9562V6)
+	assert.NoError(t, err)
+
+	v1, err := ToV1(v6)
+	assert.NoError(t, err)
+	assert.Equal(t, rfc9562V1, v1.String())
+
+	_, err = ToV1(v1)
+	assert.Error(t, err, "Should only convert V6 UUIDs")
+}
+
+func TestToV6_RoundTrip(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		v1 := NewV1()
+		v6, err := ToV6(v1)
+		assert.NoError(t, err)
+		back, err := ToV1(v6)
+		assert.NoError(t, err)
+		assert.Equal(t, v1, back, "Conversion should be lossless")
+	}
+}
+
+func TestParse(t *testing.T) {
+	valid := []string{
+		"6ba7b8149dad11d180b400c04fd430c8",
+		"6ba7b814-9dad-11d1-80b4-00c04fd430c8",
+		"{6ba7b814-9dad-11d1-80b4-00c04fd430c8}",
+		"urn:uuid:6ba7b814-9dad-11d1-80b4-00c04fd430c8",
+		"[6ba7b814-9dad-11d1-80b4-00c04fd430c8]",
+		"(6ba7b814-9dad-11d1-80b4-00c04fd430c8)",
+		"00000000-0000-0000-0000-000000000000",
+		"ffffffff-ffff-ffff-ffff-ffffffffffff",
+		rfc9562V6,
+		"017f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+		"320c3d4d-cc00-875b-8ec9-32d5f69181c0",
+		"6ba7b814-9dad-f1d1-80b4-00c04fd430c8",
+	}
+	for _, v := range valid {
+		id, err := Parse(v)
+		assert.NoError(t, err, "Should parse %s", v)
+		assert.Len(t, id, length)
+	}
+
+	invalid := []string{
+		"",
+		"6ba7b814-9dad-11d1-80b4-00c04fd430c",
+		"6ba7b814-9dad-11d1-80b4-00c04fd430c8a",
+		"6ba7b814-9dad-11d1-80b4-00c04fd430cg",
+		"uuid:6ba7b814-9dad-11d1-80b4-00c04fd430c8",
+	}
+	for _, v := range invalid {
+		_, err := Parse(v)
+		assert.ErrorIs(t, err, ErrInvalidFormat, "Should not parse %s", v)
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	valid := []string{
+		"6ba7b814-9dad-11d1-80b4-00c04fd430c8",
+		rfc9562V6,
+		"017f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+		"320c3d4d-cc00-875b-8ec9-32d5f69181c0",
+		"00000000-0000-0000-0000-000000000000",
+		"ffffffff-ffff-ffff-ffff-ffffffffffff",
+	}
+	for _, v := range valid {
+		_, err := ParseStrict(v)
+		assert.NoError(t, err, "Should parse %s", v)
+	}
+
+	_, err := ParseStrict("6ba7b814-9dad-01d1-80b4-00c04fd430c8")
+	assert.Equal(t, ErrUnknownVersion, err)
+
+	_, err = ParseStrict("6ba7b814-9dad-f1d1-80b4-00c04fd430c8")
+	assert.Equal(t, ErrUnknownVersion, err)
+
+	_, err = ParseStrict("6ba7b814-9dad-11d1-c0b4-00c04fd430c8")
+	assert.Equal(t, ErrInvalidVariant, err)
+
+	_, err = ParseStrict("6ba7b814-9dad-11d1-80b4-00c04fd430")
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+}
+
+func TestParse_Shapes(t *testing.T) {
+	invalid := []string{
+		"{6ba7b814-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b814-9dad-11d1-80b4-00c04fd430c8}",
+		"{6ba7b814-9dad-11d1-80b4-00c04fd430c8)",
+		"6ba7b8149dad-11d1-80b4-00c04fd430c8",
+		"6ba7b814-9dad-11d180b4-00c04fd430c8x",
+		"6ba7b814-9dad-11d1-80b4-00c04fd430c8--",
+		"6ba7b814+9dad+11d1+80b4+00c04fd430c8",
+		"{}",
+		"{",
+	}
+	for _, v := range invalid {
+		_, err := Parse(v)
+		assert.Error(t, err, "Should not parse %s", v)
+	}
+
+	id, err := Parse("URN:UUID:{6BA7B814-9DAD-11D1-80B4-00C04FD430C8}")
+	assert.NoError(t, err)
+	assert.True(t, Equal(id, NameSpaceX500))
+}
+
+func TestParseError(t *testing.T) {
+	cases := []struct {
+		input  string
+		offset int
+		reason ParseErrorReason
+	}{
+		{"", 0, ReasonBadLength},
+		{"6ba7b814-9dad-11d1-80b4-00c04fd430c", 35, ReasonBadLength},
+		{"6ba7b814-9dad-11d1-80b4-00c04fd430c8a", 36, ReasonBadLength},
+		{"{6ba7b814-9dad-11d1-80b4-00c04fd430c8aa}", 37, ReasonBadLength},
+		{"6ba7b814-9dad-11d1-80b4-00c04fd430cg", 35, ReasonBadHexDigit},
+		{"6ba7b814-9dxd-11d1-80b4-00c04fd430c8", 11, ReasonBadHexDigit},
+		{"urn:uuid:6ba7b814-9dad-11d1-80b4-00c04fd43zc8", 42, ReasonBadHexDigit},
+		{"6ba7b8149-dad-11d1-80b4-00c04fd430c8", 8, ReasonMisplacedHyphen},
+		{"6ba7b814-9dad-11d1-80b4-00c04fd4-0c8", 32, ReasonMisplacedHyphen},
+		{"6ba7b8149dad11d180b400c04fd43-c8", 29, ReasonMisplacedHyphen},
+		{"{6ba7b814-9dad-11d1-80b4-00c04fd430c8", 36, ReasonMismatchedBrackets},
+		{"6ba7b814-9dad-11d1-80b4-00c04fd430c8}", 36, ReasonMismatchedBrackets},
+		{"(6ba7b814-9dad-11d1-80b4-00c04fd430c8]", 37, ReasonMismatchedBrackets},
+		{"{", 0, ReasonMismatchedBrackets},
+		{"urn:uid:6ba7b814-9dad-11d1-80b4-00c04fd430c8", 5, ReasonBadURNPrefix},
+		{"uuid:6ba7b814-9dad-11d1-80b4-00c04fd430c8", 1, ReasonBadURNPrefix},
+		{"urn:uuid", 8, ReasonBadURNPrefix},
+	}
+	for _, v := range cases {
+		_, err := Parse(v.input)
+
+		var pErr *ParseError
+		if assert.True(t, errors.As(err, &pErr), "Should be a ParseError %s", v.input) {
+			assert.Equal(t, v.input, pErr.Input)
+			assert.Equal(t, v.offset, pErr.Offset, "Offset of %s", v.input)
+			assert.Equal(t, v.reason, pErr.Reason, "Reason for %s", v.input)
+			assert.Contains(t, pErr.Error(), v.reason.String())
+		}
+	}
+
+	id := Uuid{}
+	err := id.UnmarshalText([]byte("6ba7b814-9dad-11d1-80b4-00c04fd430cx"))
+	var pErr *ParseError
+	assert.True(t, errors.As(err, &pErr), "UnmarshalText should return a ParseError")
+
+	err = id.Scan("6ba7b814-9dad-11d1-80b4")
+	assert.True(t, errors.As(err, &pErr), "Scan should return a ParseError")
+}
+
+func TestParse_Allocations(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		parse("urn:uuid:6ba7b814-9dad-11d1-80b4-00c04fd430c8")
+	})
+	assert.Equal(t, float64(0), allocs, "Parsing into an array should not allocate")
+}
+
+// The regular expression implementation which parse replaced, kept for
+// comparison in benchmarks.
+var parseUUIDRegex = regexp.MustCompile(`^(urn\:uuid\:)?[\{\(\[]?([[:xdigit:]]{8})-?([[:xdigit:]]{4})-?([[:xdigit:]]{4})-?([[:xdigit:]]{4})-?([[:xdigit:]]{12})[\]\}\)]?$`)
+
+func parseRegex(pUuid string) ([]byte, error) {
+	md := parseUUIDRegex.FindStringSubmatch(pUuid)
+	if md == nil {
+		return nil, ErrInvalidFormat
+	}
+	return hex.DecodeString(md[2] + md[3] + md[4] + md[5] + md[6])
+}
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Parse("6ba7b814-9dad-11d1-80b4-00c04fd430c8")
+	}
+}
+
+func BenchmarkParse_Array(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		parse("6ba7b814-9dad-11d1-80b4-00c04fd430c8")
+	}
+}
+
+func BenchmarkParse_Urn(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		parse("urn:uuid:6ba7b814-9dad-11d1-80b4-00c04fd430c8")
+	}
+}
+
+func BenchmarkParse_Regex(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		parseRegex("6ba7b814-9dad-11d1-80b4-00c04fd430c8")
+	}
+}
+
+func BenchmarkParse_RegexUrn(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		parseRegex("urn:uuid:6ba7b814-9dad-11d1-80b4-00c04fd430c8")
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	id, err := ParseHex("6ba7b8149dad11d180b400c04fd430c8")
+	assert.NoError(t, err)
+	assert.True(t, Equal(id, NameSpaceX500))
+
+	var pErr *ParseError
+	_, err = ParseHex("6ba7b814-9dad-11d1-80b4-00c04fd430c8")
+	if assert.True(t, errors.As(err, &pErr)) {
+		assert.Equal(t, ReasonBadLength, pErr.Reason)
+	}
+
+	_, err = ParseHex("6ba7b8149dad11d180b400c04fd430cz")
+	if assert.True(t, errors.As(err, &pErr)) {
+		assert.Equal(t, ReasonBadHexDigit, pErr.Reason)
+		assert.Equal(t, 31, pErr.Offset)
+	}
+}
+
+func TestNoPanic(t *testing.T) {
+	short := []UUID{Uuid(nil), Uuid{}, Uuid{0x01, 0x02}, Immutable(""), Immutable("\x01")}
+
+	for _, v := range short {
+		assert.NotPanics(t, func() {
+			assert.Equal(t, Unknown, v.Version())
+			assert.Equal(t, VariantNCS, v.Variant())
+			assert.Len(t, v.String(), canonicalLength)
+			Formatter(v, FormatUrn)
+			Compare(v, NameSpaceDNS)
+			Compare(NameSpaceDNS, v)
+			ToV6(v)
+			ToV1(v)
+			NewV3(v, Name("test"))
+			NewV5(v, Name("test"))
+		})
+	}
+
+	assert.NotPanics(t, func() {
+		assert.Equal(t, 0, Compare(nil, Nil))
+		assert.Equal(t, NewV3(Nil, Name("test")), NewV3(nil, Name("test")))
+		assert.Equal(t, NewV5(Nil, Name("test")), NewV5(nil, Name("test")))
+		ToV6(nil)
+		ToV1(nil)
+		assert.Equal(t, Formatter(Nil, FormatUrn), Formatter(nil, FormatUrn))
+		assert.True(t, Equal(nil, nil))
+		assert.True(t, Equal(nil, Uuid(nil)))
+		assert.False(t, Equal(nil, NameSpaceDNS))
+		assert.False(t, Equal(NameSpaceDNS, nil))
+	})
+}
+
+func TestNewV3_NewV5(t *testing.T) {
+	// Test vectors from RFC9562 Appendix A
+	assert.Equal(t, "5df41881-3aed-3515-88a7-2f4a814cf09e", NewV3(NameSpaceDNS, Name("www.example.com")).String())
+	assert.Equal(t, "2ed6657d-e927-568b-95e1-2665a8aea6a2", NewV5(NameSpaceDNS, Name("www.example.com")).String())
+
+	assert.Equal(t, NewV5(NameSpaceDNS, Name("www."), Name("example.com")), NewV5(NameSpaceDNS, Name("www.example.com")))
+}
@@ -17,6 +17,9 @@ const (
 	Three                  // Namespace hash uses MD5
 	Four                   // Crypto random
 	Five                   // Namespace hash uses SHA-1
+	Six                    // Time based reordered for sorting
+	Seven                  // Unix epoch time-ordered
+	Eight                  // Custom vendor specific layout
 )
 
 const (
@@ -42,6 +45,12 @@ func (o Version) String() string {
 		return "Version 4: Crypto-random"
 	case Five:
 		return "Version 5: Namespace UUID and unique names hashed by SHA-1"
+	case Six:
+		return "Version 6: Based on a reordered 60 bit Timestamp"
+	case Seven:
+		return "Version 7: Based on a 48 bit Unix epoch millisecond timestamp"
+	case Eight:
+		return "Version 8: Custom vendor specific layout"
 	default:
 		return "Unknown: Not supported"
 	}
@@ -49,13 +58,26 @@ func (o Version) String() string {
 
 func resolveVersion(pVersion uint8) Version {
 	switch Version(pVersion) {
-	case One, Two, Three, Four, Five:
+	case One, Two, Three, Four, Five, Six, Seven, Eight:
 		return Version(pVersion)
 	default:
 		return Unknown
 	}
 }
 
+// variantName returns the name of a variant as returned by variant.
+func variantName(pVariant uint8) string {
+	switch pVariant {
+	case VariantRFC4122:
+		return "RFC4122"
+	case VariantMicrosoft:
+		return "Microsoft"
+	case VariantFuture:
+		return "Future"
+	}
+	return "NCS"
+}
+
 func variant(pVariant uint8) uint8 {
 	switch pVariant & variantGet {
 	case VariantRFC4122, 0xA0: